@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"google.golang.org/grpc/resolver"
+)
+
+// consulScheme is the target scheme resolved through Consul, as in
+// "consul:///users-service"
+const consulScheme = "consul"
+
+// How long a blocking Consul query waits for a change, and how long to
+// wait before querying again after an error
+var (
+	watchWait  = 5 * time.Minute
+	retryDelay = time.Second
+)
+
+// consulResolverBuilder resolves consul:///<service> targets to the healthy
+// instances Consul lists for the service
+type consulResolverBuilder struct {
+	client *consulapi.Client
+}
+
+func newConsulResolverBuilder(client *consulapi.Client) *consulResolverBuilder {
+	return &consulResolverBuilder{client: client}
+}
+
+func (b *consulResolverBuilder) Scheme() string { return consulScheme }
+
+func (b *consulResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	service := strings.TrimPrefix(target.Endpoint(), "/")
+	if service == "" {
+		return nil, fmt.Errorf("invalid target %q: want %s:///<service>", target.URL.String(), consulScheme)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &consulResolver{health: b.client.Health(), service: service, cc: cc, cancel: cancel}
+	r.wg.Add(1)
+	go r.watch(ctx)
+	return r, nil
+}
+
+// consulResolver keeps one connection's addresses in step with Consul. It
+// uses blocking queries, so a change in the catalog reaches the connection
+// as soon as Consul sees it, without polling
+type consulResolver struct {
+	health  *consulapi.Health
+	service string
+	cc      resolver.ClientConn
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func (r *consulResolver) watch(ctx context.Context) {
+	defer r.wg.Done()
+	var index uint64
+	// last is the set of addresses the connection has, once known
+	var last []string
+	known := false
+	for {
+		opts := (&consulapi.QueryOptions{WaitIndex: index, WaitTime: watchWait}).WithContext(ctx)
+		entries, meta, err := r.health.Service(r.service, "", true, opts)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("Consul lookup for %s failed: %v", r.service, err)
+			r.cc.ReportError(err)
+			index, known = 0, false
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+		// The index goes backwards when Consul restarts
+		if meta.LastIndex < index {
+			index = 0
+		} else {
+			index = meta.LastIndex
+		}
+
+		addrs := make([]string, 0, len(entries))
+		for _, e := range entries {
+			host := e.Service.Address
+			if host == "" {
+				host = e.Node.Address
+			}
+			addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(e.Service.Port)))
+		}
+		slices.Sort(addrs)
+		if known && slices.Equal(addrs, last) {
+			continue
+		}
+		last, known = addrs, true
+		// An empty list drops the old instances, so calls fail fast with
+		// UNAVAILABLE instead of going to instances Consul no longer lists
+		if len(addrs) == 0 {
+			log.Printf("No healthy instances of service %s found", r.service)
+		} else {
+			log.Printf("Discovered %s at %v", r.service, addrs)
+		}
+		state := resolver.State{}
+		for _, addr := range addrs {
+			state.Addresses = append(state.Addresses, resolver.Address{Addr: addr})
+		}
+		r.cc.UpdateState(state)
+	}
+}
+
+// ResolveNow does nothing; the watch already picks up every change
+func (r *consulResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (r *consulResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	consulapi "github.com/hashicorp/consul/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "practical-three/proto/gen"
+)
+
+// fakeConsul stands in for Consul's health endpoint, including blocking
+// queries: a request with ?index=N waits until the catalog changes past N
+type fakeConsul struct {
+	mu       sync.Mutex
+	index    uint64
+	changed  chan struct{}
+	services map[string][]string // service name to host:port addresses
+}
+
+func newFakeConsul(t testing.TB) (*fakeConsul, *consulapi.Client) {
+	f := &fakeConsul{index: 1, changed: make(chan struct{}), services: map[string][]string{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	config := consulapi.DefaultConfig()
+	config.Address = strings.TrimPrefix(srv.URL, "http://")
+	client, err := consulapi.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, client
+}
+
+// set replaces the healthy instances of service
+func (f *fakeConsul) set(service string, addrs ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.services[service] = addrs
+	f.index++
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	service, ok := strings.CutPrefix(r.URL.Path, "/v1/health/service/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	wait, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64)
+	f.mu.Lock()
+	for f.index <= wait {
+		changed := f.changed
+		f.mu.Unlock()
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+		f.mu.Lock()
+	}
+	entries := []*consulapi.ServiceEntry{}
+	for _, addr := range f.services[service] {
+		host, port, _ := net.SplitHostPort(addr)
+		p, _ := strconv.Atoi(port)
+		entries = append(entries, &consulapi.ServiceEntry{
+			Node:    &consulapi.Node{Address: host},
+			Service: &consulapi.AgentService{ID: service + "-" + addr, Service: service, Address: host, Port: p},
+		})
+	}
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(f.index, 10))
+	f.mu.Unlock()
+	json.NewEncoder(w).Encode(entries)
+}
+
+// usersInstance is one users-service instance on a local TCP port. It
+// fails GetUser for the ids in errs
+type usersInstance struct {
+	pb.UnimplementedUserServiceServer
+	addr  string
+	calls atomic.Int64
+	conns atomic.Int64
+	errs  map[string]error
+}
+
+func (u *usersInstance) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.UserResponse, error) {
+	u.calls.Add(1)
+	if err := u.errs[req.Id]; err != nil {
+		return nil, err
+	}
+	return &pb.UserResponse{User: &pb.User{Id: req.Id, Name: "Ada", Email: "ada@example.com"}}, nil
+}
+
+// countingListener counts the connections it accepts
+type countingListener struct {
+	net.Listener
+	count *atomic.Int64
+}
+
+func (l countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.count.Add(1)
+	}
+	return conn, err
+}
+
+func startUsersInstance(t testing.TB) *usersInstance {
+	return startFailingUsersInstance(t, nil)
+}
+
+func startFailingUsersInstance(t testing.TB, errs map[string]error) *usersInstance {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &usersInstance{addr: lis.Addr().String(), errs: errs}
+	s := grpc.NewServer()
+	pb.RegisterUserServiceServer(s, u)
+	go s.Serve(countingListener{lis, &u.conns})
+	t.Cleanup(s.Stop)
+	return u
+}
+
+// getUser requests a user through h; safe to call from parallel benchmarks
+func getUser(t testing.TB, h http.Handler, id string) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users/"+id, nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET /api/users/%s: %d %s", id, rec.Code, rec.Body)
+	}
+}
+
+func TestGatewayFollowsCatalog(t *testing.T) {
+	consul, client := newFakeConsul(t)
+	a, b := startUsersInstance(t), startUsersInstance(t)
+	consul.set("users-service", a.addr, b.addr)
+
+	g, err := newGateway(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+	h := g.router()
+
+	// Calls are spread over both instances on one connection each
+	waitFor(t, func() bool {
+		getUser(t, h, "1")
+		return a.calls.Load() > 0 && b.calls.Load() > 0
+	})
+	for i := 0; i < 20; i++ {
+		getUser(t, h, "1")
+	}
+	if a.conns.Load() != 1 || b.conns.Load() != 1 {
+		t.Errorf("connections = %d and %d, want 1 each", a.conns.Load(), b.conns.Load())
+	}
+
+	// b leaves the catalog and c joins
+	c := startUsersInstance(t)
+	consul.set("users-service", a.addr, c.addr)
+	waitFor(t, func() bool {
+		getUser(t, h, "1")
+		return c.calls.Load() > 0
+	})
+	before := b.calls.Load()
+	for i := 0; i < 10; i++ {
+		getUser(t, h, "1")
+	}
+	if b.calls.Load() != before {
+		t.Errorf("instance removed from the catalog still received calls")
+	}
+}
+
+func TestGatewayFailsFastWithoutInstances(t *testing.T) {
+	consul, client := newFakeConsul(t)
+	a := startUsersInstance(t)
+	consul.set("users-service", a.addr)
+
+	g, err := newGateway(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+	h := g.router()
+	waitFor(t, func() bool {
+		getUser(t, h, "1")
+		return a.calls.Load() > 0
+	})
+
+	// a leaves the catalog, leaving no instance to call
+	consul.set("users-service")
+	waitFor(t, func() bool {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+		return rec.Code == http.StatusServiceUnavailable
+	})
+	before := a.calls.Load()
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+	if rec.Code != http.StatusServiceUnavailable || time.Since(start) > time.Second {
+		t.Errorf("GET with no instances = %d after %v, want a prompt %d", rec.Code, time.Since(start), http.StatusServiceUnavailable)
+	}
+	if a.calls.Load() != before {
+		t.Errorf("instance removed from the catalog still received calls")
+	}
+}
+
+func TestGatewayStopsCallsWhenRequestEnds(t *testing.T) {
+	consul, client := newFakeConsul(t)
+	a := startUsersInstance(t)
+	consul.set("users-service", a.addr)
+
+	g, err := newGateway(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+	h := g.router()
+	waitFor(t, func() bool {
+		getUser(t, h, "1")
+		return a.calls.Load() > 0
+	})
+
+	// The client has gone before the gateway calls the backend
+	before := a.calls.Load()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users/1", nil).WithContext(ctx))
+	if a.calls.Load() != before {
+		t.Errorf("backend called for a request that had already ended")
+	}
+}
+
+func TestGatewayMapsStatusCodes(t *testing.T) {
+	consul, client := newFakeConsul(t)
+	u := startFailingUsersInstance(t, map[string]error{
+		"7":   status.Error(codes.NotFound, "user 7 not found"),
+		"abc": status.Error(codes.InvalidArgument, `invalid user id "abc"`),
+		"8":   status.Error(codes.Unavailable, "database is down"),
+		"9":   status.Error(codes.Unknown, "record not found"),
+	})
+	consul.set("users-service", u.addr)
+
+	g, err := newGateway(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+	h := g.router()
+	waitFor(t, func() bool {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users/1", nil))
+		return rec.Code == http.StatusOK
+	})
+
+	for _, tc := range []struct {
+		id   string
+		code int
+		body string
+	}{
+		{"7", http.StatusNotFound, "user 7 not found"},
+		{"abc", http.StatusBadRequest, `invalid user id "abc"`},
+		{"8", http.StatusServiceUnavailable, "database is down"},
+		{"9", http.StatusInternalServerError, "record not found"},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users/"+tc.id, nil))
+		if rec.Code != tc.code || strings.TrimSpace(rec.Body.String()) != tc.body {
+			t.Errorf("GET /api/users/%s = %d %q, want %d %q", tc.id, rec.Code, rec.Body, tc.code, tc.body)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader("{")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("POST /api/users with a bad body = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func waitFor(t testing.TB, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 5s")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// getUserPerRequest serves GET /api/users/{id} the way the gateway did
+// before connections were pooled: a Consul lookup and a new connection for
+// every request
+func getUserPerRequest(consul *consulapi.Client) http.Handler {
+	r := mux.NewRouter()
+	r.HandleFunc("/api/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		services, _, err := consul.Health().Service("users-service", "", true, nil)
+		if err != nil || len(services) == 0 {
+			http.Error(w, fmt.Sprintf("Service discovery failed: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+		addr := fmt.Sprintf("%s:%d", services[0].Service.Address, services[0].Service.Port)
+		conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer conn.Close()
+		res, err := pb.NewUserServiceClient(conn).GetUser(context.Background(), &pb.GetUserRequest{Id: mux.Vars(r)["id"]})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(res.User)
+	}).Methods("GET")
+	return r
+}
+
+// BenchmarkGetUser compares a Consul lookup and dial per request with the
+// pooled connections kept up to date by the Consul watch
+func BenchmarkGetUser(b *testing.B) {
+	consul, client := newFakeConsul(b)
+	a, c := startUsersInstance(b), startUsersInstance(b)
+	consul.set("users-service", a.addr, c.addr)
+
+	b.Run("dial-per-request", func(b *testing.B) {
+		h := getUserPerRequest(client)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			getUser(b, h, "1")
+		}
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		g, err := newGateway(client)
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer g.Close()
+		h := g.router()
+		getUser(b, h, "1") // connect before timing
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			getUser(b, h, "1")
+		}
+	})
+
+	b.Run("pooled-parallel", func(b *testing.B) {
+		g, err := newGateway(client)
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer g.Close()
+		h := g.router()
+		getUser(b, h, "1")
+		b.ResetTimer()
+		b.RunParallel(func(p *testing.PB) {
+			for p.Next() {
+				getUser(b, h, "1")
+			}
+		})
+	})
+}
@@ -1,141 +1,132 @@
 package main
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"sync"
 
 	"github.com/gorilla/mux"
 	consulapi "github.com/hashicorp/consul/api"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	pb "practical-three/proto/gen"
 )
 
-// Remove global clients - we'll create them dynamically per request
-
 // A struct to hold the aggregated data
 type UserPurchaseData struct {
 	User    *pb.User    `json:"user"`
 	Product *pb.Product `json:"product"`
 }
 
-// Function to discover service address from Consul
-func discoverService(serviceName string) (string, error) {
-	config := consulapi.DefaultConfig()
-	config.Address = "consul:8500" // Use Docker service name
-	consul, err := consulapi.NewClient(config)
-	if err != nil {
-		return "", err
-	}
+// serviceConfig spreads calls across every instance the resolver finds
+const serviceConfig = `{"loadBalancingConfig": [{"round_robin": {}}]}`
 
-	services, _, err := consul.Health().Service(serviceName, "", true, nil)
-	if err != nil {
-		return "", err
-	}
-
-	if len(services) == 0 {
-		return "", fmt.Errorf("no healthy instances of service %s found", serviceName)
-	}
-
-	// Use the first healthy service instance
-	service := services[0]
-	address := fmt.Sprintf("%s:%d", service.Service.Address, service.Service.Port)
-	return address, nil
+// gateway holds the clients for the backend services. Each client keeps one
+// connection per healthy instance open for the life of the gateway, and
+// the Consul resolver adds and removes instances as the catalog changes
+type gateway struct {
+	usersClient    pb.UserServiceClient
+	productsClient pb.ProductServiceClient
+	conns          []*grpc.ClientConn
 }
 
-// Function to get a users service client by discovering it from Consul
-func getUsersServiceClient() (pb.UserServiceClient, *grpc.ClientConn, error) {
-	log.Println("Discovering users-service from Consul...")
-	usersServiceAddr, err := discoverService("users-service")
+// newGateway connects to the backend services found through consul
+func newGateway(consul *consulapi.Client, opts ...grpc.DialOption) (*gateway, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithResolvers(newConsulResolverBuilder(consul)),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+	}, opts...)
+
+	usersConn, err := grpc.NewClient(consulScheme+":///users-service", opts...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to discover users-service: %v", err)
+		return nil, fmt.Errorf("failed to connect to users-service: %v", err)
 	}
-	log.Printf("Discovered users-service at: %s", usersServiceAddr)
-
-	conn, err := grpc.Dial(usersServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	productsConn, err := grpc.NewClient(consulScheme+":///products-service", opts...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to connect to users-service: %v", err)
+		usersConn.Close()
+		return nil, fmt.Errorf("failed to connect to products-service: %v", err)
 	}
 
-	client := pb.NewUserServiceClient(conn)
-	return client, conn, nil
+	return &gateway{
+		usersClient:    pb.NewUserServiceClient(usersConn),
+		productsClient: pb.NewProductServiceClient(productsConn),
+		conns:          []*grpc.ClientConn{usersConn, productsConn},
+	}, nil
 }
 
-// Function to get a products service client by discovering it from Consul
-func getProductsServiceClient() (pb.ProductServiceClient, *grpc.ClientConn, error) {
-	log.Println("Discovering products-service from Consul...")
-	productsServiceAddr, err := discoverService("products-service")
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to discover products-service: %v", err)
-	}
-	log.Printf("Discovered products-service at: %s", productsServiceAddr)
-
-	conn, err := grpc.Dial(productsServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to connect to products-service: %v", err)
+// Close closes the connections to the backend services
+func (g *gateway) Close() {
+	for _, conn := range g.conns {
+		conn.Close()
 	}
-
-	client := pb.NewProductServiceClient(conn)
-	return client, conn, nil
 }
 
-func main() {
+// router routes the gateway's endpoints to g
+func (g *gateway) router() *mux.Router {
 	r := mux.NewRouter()
 	// User routes
-	r.HandleFunc("/api/users", createUserHandler).Methods("POST")
-	r.HandleFunc("/api/users/{id}", getUserHandler).Methods("GET")
+	r.HandleFunc("/api/users", g.createUserHandler).Methods("POST")
+	r.HandleFunc("/api/users/{id}", g.getUserHandler).Methods("GET")
 	// Product routes
-	r.HandleFunc("/api/products", createProductHandler).Methods("POST")
-	r.HandleFunc("/api/products/{id}", getProductHandler).Methods("GET")
+	r.HandleFunc("/api/products", g.createProductHandler).Methods("POST")
+	r.HandleFunc("/api/products/{id}", g.getProductHandler).Methods("GET")
 
 	// The new endpoint to get combined data
-	r.HandleFunc("/api/purchases/user/{userId}/product/{productId}", getPurchaseDataHandler).Methods("GET")
+	r.HandleFunc("/api/purchases/user/{userId}/product/{productId}", g.getPurchaseDataHandler).Methods("GET")
+	return r
+}
+
+func main() {
+	config := consulapi.DefaultConfig()
+	config.Address = "consul:8500" // Use Docker service name
+	if addr := os.Getenv("CONSUL_ADDR"); addr != "" {
+		config.Address = addr
+	}
+	consul, err := consulapi.NewClient(config)
+	if err != nil {
+		log.Fatalf("Failed to create Consul client: %v", err)
+	}
+
+	g, err := newGateway(consul)
+	if err != nil {
+		log.Fatalf("Failed to create gRPC clients: %v", err)
+	}
+	defer g.Close()
 
 	log.Println("API Gateway listening on port 8080...")
-	log.Println("Service discovery will be performed on each request via Consul")
-	http.ListenAndServe(":8080", r)
+	log.Println("Backend instances are watched in Consul and their connections reused across requests")
+	http.ListenAndServe(":8080", g.router())
 }
 
 // User Handlers
-func createUserHandler(w http.ResponseWriter, r *http.Request) {
-	// Discover and connect to users-service via Consul
-	usersClient, conn, err := getUsersServiceClient()
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Service discovery failed: %v", err), http.StatusServiceUnavailable)
+func (g *gateway) createUserHandler(w http.ResponseWriter, r *http.Request) {
+	var req pb.CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	defer conn.Close()
-
-	var req pb.CreateUserRequest
-	json.NewDecoder(r.Body).Decode(&req)
-	res, err := usersClient.CreateUser(context.Background(), &req)
+	res, err := g.usersClient.CreateUser(r.Context(), &req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeGRPCError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(res.User)
 }
 
-func getUserHandler(w http.ResponseWriter, r *http.Request) {
-	// Discover and connect to users-service via Consul
-	usersClient, conn, err := getUsersServiceClient()
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Service discovery failed: %v", err), http.StatusServiceUnavailable)
-		return
-	}
-	defer conn.Close()
-
+func (g *gateway) getUserHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
-	res, err := usersClient.GetUser(context.Background(), &pb.GetUserRequest{Id: id})
+	res, err := g.usersClient.GetUser(r.Context(), &pb.GetUserRequest{Id: id})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeGRPCError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -143,40 +134,27 @@ func getUserHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // Product Handlers
-func createProductHandler(w http.ResponseWriter, r *http.Request) {
-	// Discover and connect to products-service via Consul
-	productsClient, conn, err := getProductsServiceClient()
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Service discovery failed: %v", err), http.StatusServiceUnavailable)
+func (g *gateway) createProductHandler(w http.ResponseWriter, r *http.Request) {
+	var req pb.CreateProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	defer conn.Close()
-
-	var req pb.CreateProductRequest
-	json.NewDecoder(r.Body).Decode(&req)
-	res, err := productsClient.CreateProduct(context.Background(), &req)
+	res, err := g.productsClient.CreateProduct(r.Context(), &req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeGRPCError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(res.Product)
 }
 
-func getProductHandler(w http.ResponseWriter, r *http.Request) {
-	// Discover and connect to products-service via Consul
-	productsClient, conn, err := getProductsServiceClient()
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Service discovery failed: %v", err), http.StatusServiceUnavailable)
-		return
-	}
-	defer conn.Close()
-
+func (g *gateway) getProductHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
-	res, err := productsClient.GetProduct(context.Background(), &pb.GetProductRequest{Id: id})
+	res, err := g.productsClient.GetProduct(r.Context(), &pb.GetProductRequest{Id: id})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeGRPCError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -184,7 +162,7 @@ func getProductHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // New handler for combined data
-func getPurchaseDataHandler(w http.ResponseWriter, r *http.Request) {
+func (g *gateway) getPurchaseDataHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userId := vars["userId"]
 	productId := vars["productId"]
@@ -198,15 +176,7 @@ func getPurchaseDataHandler(w http.ResponseWriter, r *http.Request) {
 
 	go func() {
 		defer wg.Done()
-		// Discover and connect to users-service via Consul
-		usersClient, conn, err := getUsersServiceClient()
-		if err != nil {
-			userErr = fmt.Errorf("failed to discover users-service: %v", err)
-			return
-		}
-		defer conn.Close()
-
-		res, err := usersClient.GetUser(context.Background(), &pb.GetUserRequest{Id: userId})
+		res, err := g.usersClient.GetUser(r.Context(), &pb.GetUserRequest{Id: userId})
 		if err != nil {
 			userErr = err
 			return
@@ -216,15 +186,7 @@ func getPurchaseDataHandler(w http.ResponseWriter, r *http.Request) {
 
 	go func() {
 		defer wg.Done()
-		// Discover and connect to products-service via Consul
-		productsClient, conn, err := getProductsServiceClient()
-		if err != nil {
-			productErr = fmt.Errorf("failed to discover products-service: %v", err)
-			return
-		}
-		defer conn.Close()
-
-		res, err := productsClient.GetProduct(context.Background(), &pb.GetProductRequest{Id: productId})
+		res, err := g.productsClient.GetProduct(r.Context(), &pb.GetProductRequest{Id: productId})
 		if err != nil {
 			productErr = err
 			return
@@ -236,13 +198,18 @@ func getPurchaseDataHandler(w http.ResponseWriter, r *http.Request) {
 
 	if userErr != nil || productErr != nil {
 		errMsg := "Could not retrieve all data"
+		code := http.StatusInternalServerError
 		if userErr != nil {
-			errMsg += fmt.Sprintf(" - User error: %v", userErr)
+			errMsg += fmt.Sprintf(" - User error: %s", status.Convert(userErr).Message())
+			code = httpStatus(userErr)
 		}
 		if productErr != nil {
-			errMsg += fmt.Sprintf(" - Product error: %v", productErr)
+			errMsg += fmt.Sprintf(" - Product error: %s", status.Convert(productErr).Message())
+			if userErr == nil {
+				code = httpStatus(productErr)
+			}
 		}
-		http.Error(w, errMsg, http.StatusNotFound)
+		http.Error(w, errMsg, code)
 		return
 	}
 
@@ -254,3 +221,26 @@ func getPurchaseDataHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(purchaseData)
 }
+
+// httpStatus maps the gRPC status of a backend error to an HTTP status
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeGRPCError responds with the status and message of a backend error
+func writeGRPCError(w http.ResponseWriter, err error) {
+	http.Error(w, status.Convert(err).Message(), httpStatus(err))
+}
@@ -2,13 +2,21 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
@@ -20,6 +28,9 @@ import (
 const serviceName = "products-service"
 const servicePort = 50052
 
+// healthInterval is how often the database is checked for the health service
+const healthInterval = 10 * time.Second
+
 // GORM model for our Product
 type Product struct {
 	gorm.Model
@@ -33,27 +44,45 @@ type server struct {
 }
 
 func (s *server) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.ProductResponse, error) {
+	if req.Name == "" || req.Price < 0 {
+		return nil, status.Error(codes.InvalidArgument, "name is required and price must not be negative")
+	}
 	product := Product{Name: req.Name, Price: req.Price}
 	if result := s.db.Create(&product); result.Error != nil {
-		return nil, result.Error
+		return nil, status.Errorf(codes.Internal, "failed to create product: %v", result.Error)
 	}
 	return &pb.ProductResponse{Product: &pb.Product{Id: fmt.Sprint(product.ID), Name: product.Name, Price: product.Price}}, nil
 }
 
 func (s *server) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.ProductResponse, error) {
+	// A non-numeric id would otherwise be passed to GORM as raw SQL
+	id, err := strconv.ParseUint(req.Id, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product id %q", req.Id)
+	}
 	var product Product
-	if result := s.db.First(&product, req.Id); result.Error != nil {
-		return nil, result.Error
+	if result := s.db.First(&product, id); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "product %d not found", id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get product: %v", result.Error)
 	}
 	return &pb.ProductResponse{Product: &pb.Product{Id: fmt.Sprint(product.ID), Name: product.Name, Price: product.Price}}, nil
 }
 
+// gormConfig is the configuration for every database connection.
+// TranslateError turns driver errors such as unique violations into
+// gorm.Err* values
+func gormConfig() *gorm.Config {
+	return &gorm.Config{TranslateError: true}
+}
+
 func connectToDatabase(dsn string, maxRetries int) (*gorm.DB, error) {
 	var db *gorm.DB
 	var err error
 
 	for i := 0; i < maxRetries; i++ {
-		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		db, err = gorm.Open(postgres.Open(dsn), gormConfig())
 		if err == nil {
 			return db, nil
 		}
@@ -84,36 +113,103 @@ func main() {
 	s := grpc.NewServer()
 	pb.RegisterProductServiceServer(s, &server{db: db})
 
-	// 3. Register with Consul
-	if err := registerServiceWithConsul(); err != nil {
+	// 3. Serve grpc.health.v1, SERVING only while the database responds
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	go watchDatabase(ctx, db, healthServer)
+
+	// 4. Register with Consul, which polls the health service
+	deregister, err := registerServiceWithConsul()
+	if err != nil {
 		log.Fatalf("Failed to register with Consul: %v", err)
 	}
 
-	log.Printf("%s gRPC server listening at %v", serviceName, lis.Addr())
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	go func() {
+		log.Printf("%s gRPC server listening at %v", serviceName, lis.Addr())
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("Failed to serve: %v", err)
+		}
+	}()
+
+	// 5. On SIGINT/SIGTERM stop taking new calls, leave Consul and let
+	// in-flight calls finish
+	<-ctx.Done()
+	log.Printf("Shutting down %s", serviceName)
+	healthServer.Shutdown()
+	if err := deregister(); err != nil {
+		log.Printf("Failed to deregister from Consul: %v", err)
+	}
+	s.GracefulStop()
+	log.Printf("%s stopped", serviceName)
+}
+
+// watchDatabase sets the health status from a database ping every
+// healthInterval until ctx is done
+func watchDatabase(ctx context.Context, db *gorm.DB, healthServer *health.Server) {
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+	for {
+		servingStatus := healthpb.HealthCheckResponse_SERVING
+		if err := pingDatabase(ctx, db); err != nil {
+			log.Printf("Database health check failed: %v", err)
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		healthServer.SetServingStatus("", servingStatus)
+		healthServer.SetServingStatus(pb.ProductService_ServiceDesc.ServiceName, servingStatus)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func pingDatabase(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
 	}
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
 }
 
-func registerServiceWithConsul() error {
+// registerServiceWithConsul registers the service with a gRPC health check
+// and returns a function that deregisters it
+func registerServiceWithConsul() (func() error, error) {
 	config := consulapi.DefaultConfig()
 	config.Address = "consul:8500" // Use Docker service name
 	consul, err := consulapi.NewClient(config)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	hostname, err := os.Hostname()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	serviceID := fmt.Sprintf("%s-%s", serviceName, hostname)
 	registration := &consulapi.AgentServiceRegistration{
-		ID:      fmt.Sprintf("%s-%s", serviceName, hostname),
+		ID:      serviceID,
 		Name:    serviceName,
 		Port:    servicePort,
 		Address: hostname,
+		Check: &consulapi.AgentServiceCheck{
+			// Consul calls grpc.health.v1.Health/Check for the service
+			GRPC:     fmt.Sprintf("%s:%d/%s", hostname, servicePort, pb.ProductService_ServiceDesc.ServiceName),
+			Interval: "10s",
+			Timeout:  "1s",
+			// Clean up after an instance that died without deregistering
+			DeregisterCriticalServiceAfter: "1m",
+		},
 	}
 
-	return consul.Agent().ServiceRegister(registration)
+	if err := consul.Agent().ServiceRegister(registration); err != nil {
+		return nil, err
+	}
+	return func() error { return consul.Agent().ServiceDeregister(serviceID) }, nil
 }
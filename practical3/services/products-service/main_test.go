@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	pb "practical-three/proto/gen"
+)
+
+// newTestServer returns a server backed by an in-memory database of its own
+func newTestServer(t *testing.T) *server {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())), gormConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&Product{}); err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return &server{db: db}
+}
+
+func TestCreateAndGetProduct(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	created, err := s.CreateProduct(ctx, &pb.CreateProductRequest{Name: "Coffee", Price: 2.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetProduct(ctx, &pb.GetProductRequest{Id: created.Product.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Product.Name != "Coffee" || got.Product.Price != 2.5 {
+		t.Errorf("GetProduct(%s) = %v, want Coffee at 2.5", created.Product.Id, got.Product)
+	}
+}
+
+func TestGetProductErrors(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, tc := range []struct {
+		id   string
+		code codes.Code
+	}{
+		{"42", codes.NotFound},
+		{"abc", codes.InvalidArgument},
+		{"1 OR 1=1", codes.InvalidArgument},
+		{"", codes.InvalidArgument},
+	} {
+		_, err := s.GetProduct(context.Background(), &pb.GetProductRequest{Id: tc.id})
+		if status.Code(err) != tc.code {
+			t.Errorf("GetProduct(%q) = %v, want %s", tc.id, err, tc.code)
+		}
+	}
+}
+
+func TestCreateProductErrors(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, req := range []*pb.CreateProductRequest{{Price: 1}, {Name: "Coffee", Price: -1}} {
+		if _, err := s.CreateProduct(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateProduct(%v) = %v, want %s", req, err, codes.InvalidArgument)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	pb "practical-three/proto/gen"
+)
+
+// newTestServer returns a server backed by an in-memory database of its own
+func newTestServer(t *testing.T) *server {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())), gormConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&User{}); err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return &server{db: db}
+}
+
+func TestCreateAndGetUser(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	created, err := s.CreateUser(ctx, &pb.CreateUserRequest{Name: "Ada", Email: "ada@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetUser(ctx, &pb.GetUserRequest{Id: created.User.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.User.Name != "Ada" || got.User.Email != "ada@example.com" {
+		t.Errorf("GetUser(%s) = %v, want Ada <ada@example.com>", created.User.Id, got.User)
+	}
+}
+
+func TestGetUserErrors(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, tc := range []struct {
+		id   string
+		code codes.Code
+	}{
+		{"42", codes.NotFound},
+		{"abc", codes.InvalidArgument},
+		{"1 OR 1=1", codes.InvalidArgument},
+		{"", codes.InvalidArgument},
+	} {
+		_, err := s.GetUser(context.Background(), &pb.GetUserRequest{Id: tc.id})
+		if status.Code(err) != tc.code {
+			t.Errorf("GetUser(%q) = %v, want %s", tc.id, err, tc.code)
+		}
+	}
+}
+
+func TestCreateUserErrors(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	if _, err := s.CreateUser(ctx, &pb.CreateUserRequest{Name: "Ada", Email: "ada@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	// The unique index on email is reported through TranslateError
+	_, err := s.CreateUser(ctx, &pb.CreateUserRequest{Name: "Another Ada", Email: "ada@example.com"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateUser with a duplicate email = %v, want %s", err, codes.AlreadyExists)
+	}
+
+	for _, req := range []*pb.CreateUserRequest{{Name: "Ada"}, {Email: "ada@example.com"}} {
+		if _, err := s.CreateUser(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateUser(%v) = %v, want %s", req, err, codes.InvalidArgument)
+		}
+	}
+}
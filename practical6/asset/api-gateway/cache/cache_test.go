@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c := NewLRU[string, int](2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	_, _ = c.Get("a") // a is now the most recently used
+	c.Add("c", 3)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok, "least recently used entry is evicted")
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 2, c.Len())
+
+	now = now.Add(time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok, "entries expire after the TTL")
+	assert.Equal(t, 1, c.Len())
+
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestMenuCacheDropsEntriesFromOlderVersions(t *testing.T) {
+	c := NewMenuCache(DefaultSize, time.Minute)
+	c.SetVersion("v1")
+	ctx := context.Background()
+
+	calls := 0
+	fetch := func(context.Context) ([]byte, error) {
+		calls++
+		return []byte(`{"calls":` + strconv.Itoa(calls) + `}`), nil
+	}
+
+	first, err := c.Fetch(ctx, "menu", fetch)
+	require.NoError(t, err)
+	second, err := c.Fetch(ctx, "menu", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, first, second)
+
+	// The same version leaves entries alone
+	c.SetVersion("v1")
+	_, err = c.Fetch(ctx, "menu", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	// A new version means the menu changed
+	c.SetVersion("v2")
+	third, err := c.Fetch(ctx, "menu", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.NotEqual(t, first.ETag, third.ETag)
+
+	// A response fetched under v2 is not served once v3 is known, even if
+	// it was stored after the change was seen
+	slow := func(context.Context) ([]byte, error) {
+		c.SetVersion("v3")
+		return []byte(`stale`), nil
+	}
+	_, err = c.Fetch(ctx, "item:1", slow)
+	require.NoError(t, err)
+	_, err = c.Fetch(ctx, "item:1", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	// Nor is one that was in flight when the cache was invalidated
+	invalidated := func(context.Context) ([]byte, error) {
+		c.Invalidate()
+		return []byte(`stale`), nil
+	}
+	_, err = c.Fetch(ctx, "item:3", invalidated)
+	require.NoError(t, err)
+	_, err = c.Fetch(ctx, "item:3", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 4, calls)
+
+	// Errors are returned and not cached
+	_, err = c.Fetch(ctx, "item:2", func(context.Context) ([]byte, error) { return nil, errors.New("unavailable") })
+	assert.Error(t, err)
+	_, err = c.Fetch(ctx, "item:2", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 5, calls)
+}
+
+func TestNilMenuCacheAlwaysFetches(t *testing.T) {
+	var c *MenuCache
+	calls := 0
+	for i := 0; i < 2; i++ {
+		entry, err := c.Fetch(context.Background(), "menu", func(context.Context) ([]byte, error) {
+			calls++
+			return []byte(`[]`), nil
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, entry.ETag)
+	}
+	assert.Equal(t, 2, calls)
+	c.Invalidate()
+}
+
+func TestWrite(t *testing.T) {
+	entry := NewEntry([]byte(`[{"id":1}]`))
+
+	rec := httptest.NewRecorder()
+	Write(rec, httptest.NewRequest(http.MethodGet, "/api/menu", nil), entry)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, entry.ETag, rec.Header().Get("ETag"))
+	assert.Equal(t, CacheControl, rec.Header().Get("Cache-Control"))
+	assert.Equal(t, `[{"id":1}]`, rec.Body.String())
+
+	for _, ifNoneMatch := range []string{entry.ETag, `"other", ` + entry.ETag, "W/" + entry.ETag, "*"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/menu", nil)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		rec := httptest.NewRecorder()
+		Write(rec, req, entry)
+		assert.Equal(t, http.StatusNotModified, rec.Code, ifNoneMatch)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, entry.ETag, rec.Header().Get("ETag"))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/menu", nil)
+	req.Header.Set("If-None-Match", `"other"`)
+	rec = httptest.NewRecorder()
+	Write(rec, req, entry)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
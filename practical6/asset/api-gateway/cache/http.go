@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"net/http"
+	"strings"
+)
+
+// CacheControl lets clients and proxies store menu responses but requires
+// them to revalidate each time; the gateway answers revalidations from its
+// own cache with 304 Not Modified, so they stay cheap without ever serving
+// a menu that has changed
+const CacheControl = "public, no-cache"
+
+// Write serves entry as JSON with its ETag, or 304 Not Modified when the
+// request's If-None-Match already names it
+func Write(w http.ResponseWriter, r *http.Request, entry Entry) {
+	h := w.Header()
+	h.Set("ETag", entry.ETag)
+	h.Set("Cache-Control", CacheControl)
+	if NotModified(r, entry.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.Set("Content-Type", "application/json")
+	w.Write(entry.Body)
+}
+
+// NotModified reports whether the request's If-None-Match header matches
+// etag, using the weak comparison required for If-None-Match
+func NotModified(r *http.Request, etag string) bool {
+	header := strings.Join(r.Header.Values("If-None-Match"), ",")
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"sync"
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+)
+
+// Defaults for the menu cache
+const (
+	DefaultSize         = 1024
+	DefaultTTL          = 30 * time.Second
+	DefaultPollInterval = 2 * time.Second
+)
+
+// Entry is an encoded response ready to be served
+type Entry struct {
+	Body []byte
+	ETag string
+	// generation is the cache generation current when the response was
+	// fetched
+	generation uint64
+}
+
+// NewEntry wraps an encoded response body, deriving its ETag from the body
+func NewEntry(body []byte) Entry {
+	sum := sha256.Sum256(body)
+	return Entry{Body: body, ETag: `"` + hex.EncodeToString(sum[:16]) + `"`}
+}
+
+// MenuCache caches encoded menu responses. Entries expire after a TTL and
+// are dropped as soon as menu-service reports a new menu version, so a
+// response fetched just before a change is never served after it. A nil
+// *MenuCache caches nothing.
+type MenuCache struct {
+	entries *LRU[string, Entry]
+
+	mu      sync.RWMutex
+	version string
+	// generation is bumped on every version change and invalidation, so
+	// responses fetched before either are never served
+	generation uint64
+}
+
+// NewMenuCache creates a MenuCache holding up to size responses for ttl each
+func NewMenuCache(size int, ttl time.Duration) *MenuCache {
+	return &MenuCache{entries: NewLRU[string, Entry](size, ttl)}
+}
+
+// Fetch returns the cached response for key, or calls fetch and caches what
+// it returns
+func (c *MenuCache) Fetch(ctx context.Context, key string, fetch func(context.Context) ([]byte, error)) (Entry, error) {
+	if c == nil {
+		body, err := fetch(ctx)
+		if err != nil {
+			return Entry{}, err
+		}
+		return NewEntry(body), nil
+	}
+
+	generation := c.currentGeneration()
+	if entry, ok := c.entries.Get(key); ok {
+		if entry.generation == generation {
+			return entry, nil
+		}
+		c.entries.Remove(key)
+	}
+
+	body, err := fetch(ctx)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry := NewEntry(body)
+	entry.generation = generation
+	c.entries.Add(key, entry)
+	return entry, nil
+}
+
+// Version returns the last menu version reported by menu-service
+func (c *MenuCache) Version() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.version
+}
+
+// currentGeneration returns the generation new entries are tagged with
+func (c *MenuCache) currentGeneration() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.generation
+}
+
+// SetVersion records the menu version reported by menu-service, dropping
+// every entry when it has changed
+func (c *MenuCache) SetVersion(version string) {
+	c.mu.Lock()
+	changed := version != c.version
+	c.version = version
+	if changed {
+		c.generation++
+	}
+	c.mu.Unlock()
+
+	if changed {
+		c.entries.Purge()
+		slog.Debug("Menu changed, cache invalidated", "version", version)
+	}
+}
+
+// Invalidate drops every entry, e.g. after the gateway itself changed the
+// menu. Responses still being fetched are served but not stored, as they may
+// predate the change.
+func (c *MenuCache) Invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.generation++
+	c.mu.Unlock()
+	c.entries.Purge()
+}
+
+// Watch asks menu-service for the menu version every interval until ctx is
+// done. While menu-service is unreachable entries are kept until they
+// expire.
+func (c *MenuCache) Watch(ctx context.Context, client menuv1.MenuServiceClient, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Poll(ctx, client); err != nil && ctx.Err() == nil {
+			slog.Warn("Failed to get menu version", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll asks menu-service for the menu version once
+func (c *MenuCache) Poll(ctx context.Context, client menuv1.MenuServiceClient) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultPollInterval)
+	defer cancel()
+	resp, err := client.GetMenuVersion(ctx, &menuv1.GetMenuVersionRequest{})
+	if err != nil {
+		return err
+	}
+	c.SetVersion(resp.Version)
+	return nil
+}
@@ -2,14 +2,15 @@ package grpc
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
 
+	"github.com/douglasswm/student-cafe-common/grpcclient"
+	"github.com/douglasswm/student-cafe-common/health"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 )
 
 // ServiceClients holds all gRPC clients for backend services
@@ -17,35 +18,35 @@ type ServiceClients struct {
 	UserClient  userv1.UserServiceClient
 	MenuClient  menuv1.MenuServiceClient
 	OrderClient orderv1.OrderServiceClient
+	// HealthChecks probe each backend's grpc.health.v1 service
+	HealthChecks []health.Check
+	// conns are closed by Close on shutdown
+	conns []*grpcclient.Conn
 }
 
-// NewServiceClients creates and initializes gRPC clients for all backend services
-func NewServiceClients() (*ServiceClients, error) {
-	// Get service addresses from environment or use defaults
-	userAddr := getEnv("USER_SERVICE_GRPC_ADDR", "user-service:9091")
-	menuAddr := getEnv("MENU_SERVICE_GRPC_ADDR", "menu-service:9092")
-	orderAddr := getEnv("ORDER_SERVICE_GRPC_ADDR", "order-service:9093")
+// NewServiceClients creates and initializes gRPC clients for all backend
+// services, connecting with creds and any further dial options, such as
+// the resolver for discovery:/// addresses
+func NewServiceClients(userAddr, menuAddr, orderAddr string, creds credentials.TransportCredentials, opts ...grpc.DialOption) (*ServiceClients, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, opts...)
 
-	log.Printf("Connecting to User Service at %s", userAddr)
+	slog.Info("Connecting to User Service", "addr", userAddr)
 	// Create gRPC connection to user service
-	userConn, err := grpc.NewClient(userAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	userConn, err := grpcclient.Dial(userAddr, grpcclient.UserServicePolicy(), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to user service: %w", err)
 	}
 
-	log.Printf("Connecting to Menu Service at %s", menuAddr)
+	slog.Info("Connecting to Menu Service", "addr", menuAddr)
 	// Create gRPC connection to menu service
-	menuConn, err := grpc.NewClient(menuAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	menuConn, err := grpcclient.Dial(menuAddr, grpcclient.MenuServicePolicy(), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to menu service: %w", err)
 	}
 
-	log.Printf("Connecting to Order Service at %s", orderAddr)
+	slog.Info("Connecting to Order Service", "addr", orderAddr)
 	// Create gRPC connection to order service
-	orderConn, err := grpc.NewClient(orderAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	orderConn, err := grpcclient.Dial(orderAddr, grpcclient.OrderServicePolicy(), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to order service: %w", err)
 	}
@@ -54,12 +55,18 @@ func NewServiceClients() (*ServiceClients, error) {
 		UserClient:  userv1.NewUserServiceClient(userConn),
 		MenuClient:  menuv1.NewMenuServiceClient(menuConn),
 		OrderClient: orderv1.NewOrderServiceClient(orderConn),
+		HealthChecks: []health.Check{
+			health.GRPCCheck("user-service", userConn.HealthConn()),
+			health.GRPCCheck("menu-service", menuConn.HealthConn()),
+			health.GRPCCheck("order-service", orderConn.HealthConn()),
+		},
+		conns: []*grpcclient.Conn{userConn, menuConn, orderConn},
 	}, nil
 }
 
-func getEnv(key, defaultVal string) string {
-	if val := os.Getenv(key); val != "" {
-		return val
+// Close closes the connections to all backend services
+func (c *ServiceClients) Close() {
+	for _, conn := range c.conns {
+		conn.Close()
 	}
-	return defaultVal
 }
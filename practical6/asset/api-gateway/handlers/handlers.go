@@ -3,53 +3,46 @@ package handlers
 import (
 	"net/http"
 
+	"api-gateway/cache"
 	"api-gateway/grpc"
-
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"api-gateway/render"
 )
 
 // Handlers holds the HTTP handlers and gRPC clients
 type Handlers struct {
-	clients *grpc.ServiceClients
+	clients   *grpc.ServiceClients
+	menuCache *cache.MenuCache
 }
 
-// NewHandlers creates a new Handlers instance with gRPC clients
-func NewHandlers(clients *grpc.ServiceClients) *Handlers {
-	return &Handlers{clients: clients}
+// Option configures optional Handlers behaviour
+type Option func(*Handlers)
+
+// WithMenuCache serves menu reads from c
+func WithMenuCache(c *cache.MenuCache) Option {
+	return func(h *Handlers) { h.menuCache = c }
 }
 
-// handleGRPCError converts gRPC errors to appropriate HTTP status codes
-func handleGRPCError(w http.ResponseWriter, err error) {
-	st, ok := status.FromError(err)
-	if !ok {
-		// Not a gRPC error, return generic internal server error
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-		return
+// NewHandlers creates a new Handlers instance with gRPC clients
+func NewHandlers(clients *grpc.ServiceClients, opts ...Option) *Handlers {
+	h := &Handlers{clients: clients}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
+}
 
-	// Map gRPC status codes to HTTP status codes
-	var httpStatus int
-	switch st.Code() {
-	case codes.NotFound:
-		httpStatus = http.StatusNotFound
-	case codes.InvalidArgument:
-		httpStatus = http.StatusBadRequest
-	case codes.AlreadyExists:
-		httpStatus = http.StatusConflict
-	case codes.PermissionDenied:
-		httpStatus = http.StatusForbidden
-	case codes.Unauthenticated:
-		httpStatus = http.StatusUnauthorized
-	case codes.FailedPrecondition:
-		httpStatus = http.StatusPreconditionFailed
-	case codes.Unimplemented:
-		httpStatus = http.StatusNotImplemented
-	case codes.Unavailable:
-		httpStatus = http.StatusServiceUnavailable
-	default:
-		httpStatus = http.StatusInternalServerError
-	}
+// handleGRPCError converts gRPC errors to appropriate HTTP status codes and
+// writes them as a JSON error envelope
+func handleGRPCError(w http.ResponseWriter, r *http.Request, err error) {
+	render.GRPCError(w, r, err)
+}
+
+// invalidID rejects a malformed path ID
+func invalidID(w http.ResponseWriter, r *http.Request, what string) {
+	render.BadRequest(w, r, "invalid "+what+" ID", render.FieldViolation{Field: "id", Description: "must be a positive integer"})
+}
 
-	http.Error(w, st.Message(), httpStatus)
+// invalidBody rejects a request body that could not be decoded
+func invalidBody(w http.ResponseWriter, r *http.Request, err error) {
+	render.BadRequest(w, r, "invalid request body: "+err.Error())
 }
@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api-gateway/cache"
+	"api-gateway/grpc"
+	"api-gateway/render"
+	"api-gateway/requestctx"
+
+	"github.com/douglasswm/student-cafe-common/health"
+	"github.com/douglasswm/student-cafe-common/telemetry"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"github.com/go-chi/chi/v5"
+	"github.com/riandyrn/otelchi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// blockingMenuServer holds GetMenu open until the call's context ends (or
+// release is closed) and reports what it saw
+type blockingMenuServer struct {
+	menuv1.UnimplementedMenuServiceServer
+	started chan metadata.MD
+	done    chan error
+	release chan struct{}
+}
+
+func (s *blockingMenuServer) GetMenu(ctx context.Context, req *menuv1.GetMenuRequest) (*menuv1.GetMenuResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.started <- md
+	select {
+	case <-ctx.Done():
+		s.done <- ctx.Err()
+		return nil, ctx.Err()
+	case <-s.release:
+		s.done <- nil
+		return &menuv1.GetMenuResponse{}, nil
+	}
+}
+
+// newTestRouter serves GetMenu from a blocking bufconn backend behind the
+// gateway middleware
+func newTestRouter(t *testing.T, timeout time.Duration) (http.Handler, *blockingMenuServer) {
+	t.Helper()
+
+	backend := &blockingMenuServer{started: make(chan metadata.MD, 1), done: make(chan error, 1), release: make(chan struct{})}
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpclib.NewServer(telemetry.ServerOption())
+	menuv1.RegisterMenuServiceServer(server, backend)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpclib.NewClient("passthrough:///bufnet",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+		telemetry.DialOption())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	h := NewHandlers(&grpc.ServiceClients{MenuClient: menuv1.NewMenuServiceClient(conn)})
+	r := chi.NewRouter()
+	r.Use(otelchi.Middleware("api-gateway", otelchi.WithChiRoutes(r)))
+	r.Use(requestctx.Middleware)
+	r.With(requestctx.Timeout(timeout)).Get("/api/menu", h.GetMenu)
+	return r, backend
+}
+
+func TestCancelledRequestCancelsBackendCall(t *testing.T) {
+	router, backend := newTestRouter(t, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/api/menu", nil).WithContext(ctx)
+	req.Header.Set(requestctx.RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+
+	served := make(chan struct{})
+	go func() {
+		router.ServeHTTP(rec, req)
+		close(served)
+	}()
+
+	// Request-scoped metadata reaches the backend
+	select {
+	case md := <-backend.started:
+		assert.Equal(t, []string{"req-42"}, md.Get(requestctx.MetadataRequestID))
+		assert.NotEmpty(t, md.Get(requestctx.MetadataForwardedFor))
+	case <-time.After(2 * time.Second):
+		t.Fatal("backend was not called")
+	}
+
+	// The client goes away: the backend call must be cancelled, not left running
+	cancel()
+	select {
+	case err := <-backend.done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("backend call was not cancelled")
+	}
+	<-served
+	assert.Equal(t, render.StatusClientClosedRequest, rec.Code)
+}
+
+func TestRouteTimeoutCancelsBackendCall(t *testing.T) {
+	router, backend := newTestRouter(t, 50*time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	start := time.Now()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/menu", nil))
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	<-backend.started
+	select {
+	case err := <-backend.done:
+		assert.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("backend call outlived the route timeout")
+	}
+}
+
+func TestRequestIsTracedAcrossGatewayAndBackend(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	_, err := telemetry.Setup(context.Background(), telemetry.Config{Exporter: telemetry.ExporterNone})
+	require.NoError(t, err)
+
+	router, backend := newTestRouter(t, time.Minute)
+	close(backend.release)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/menu", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 3)
+	var gateway, client, server sdktrace.ReadOnlySpan
+	for _, s := range ended {
+		switch {
+		case s.SpanKind() == trace.SpanKindClient:
+			client = s
+		case s.Name() == "menu.v1.MenuService/GetMenu":
+			server = s
+		default:
+			gateway = s
+		}
+	}
+	require.NotNil(t, client)
+	require.NotNil(t, server)
+	require.NotNil(t, gateway)
+
+	// HTTP request -> gRPC client call -> menu-service handler, all one trace
+	assert.Equal(t, "/api/menu", gateway.Name())
+	assert.Equal(t, gateway.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.Equal(t, gateway.SpanContext().TraceID(), server.SpanContext().TraceID())
+}
+
+// healthBackend serves grpc.health.v1 from a monitor whose single check
+// passes or fails as told
+func healthBackend(t *testing.T, healthy bool) health.Check {
+	t.Helper()
+
+	monitor := health.NewMonitor(nil, health.Check{Name: "database", Probe: func(ctx context.Context) error {
+		if !healthy {
+			return errors.New("connection refused")
+		}
+		return nil
+	}})
+	monitor.CheckNow(context.Background())
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpclib.NewServer()
+	monitor.Register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpclib.NewClient("passthrough:///bufnet",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return health.GRPCCheck("backend", conn)
+}
+
+func TestReadyzAggregatesBackendHealth(t *testing.T) {
+	serving := healthBackend(t, true)
+	serving.Name = "menu-service"
+	notServing := healthBackend(t, false)
+	notServing.Name = "order-service"
+
+	t.Run("all backends serving", func(t *testing.T) {
+		h := NewHandlers(&grpc.ServiceClients{HealthChecks: []health.Check{serving}})
+		rec := httptest.NewRecorder()
+		h.Readyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp ReadinessResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Equal(t, "ready", resp.Status)
+		assert.Equal(t, map[string]string{"menu-service": "SERVING"}, resp.Services)
+	})
+
+	t.Run("a backend not serving", func(t *testing.T) {
+		h := NewHandlers(&grpc.ServiceClients{HealthChecks: []health.Check{serving, notServing}})
+		rec := httptest.NewRecorder()
+		h.Readyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		var resp ReadinessResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Equal(t, "unavailable", resp.Status)
+		assert.Equal(t, "SERVING", resp.Services["menu-service"])
+		assert.Contains(t, resp.Services["order-service"], "NOT_SERVING")
+	})
+
+	t.Run("healthz ignores backends", func(t *testing.T) {
+		h := NewHandlers(&grpc.ServiceClients{HealthChecks: []health.Check{notServing}})
+		rec := httptest.NewRecorder()
+		h.Healthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+// countingMenuClient serves a one-item menu at the given price and
+// version, counting GetMenu calls
+type countingMenuClient struct {
+	menuv1.MenuServiceClient
+	price    float64
+	version  string
+	getMenus int
+}
+
+func (c *countingMenuClient) GetMenu(ctx context.Context, in *menuv1.GetMenuRequest, opts ...grpclib.CallOption) (*menuv1.GetMenuResponse, error) {
+	c.getMenus++
+	return &menuv1.GetMenuResponse{MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Coffee", Price: c.price}}}, nil
+}
+
+func (c *countingMenuClient) GetMenuVersion(ctx context.Context, in *menuv1.GetMenuVersionRequest, opts ...grpclib.CallOption) (*menuv1.GetMenuVersionResponse, error) {
+	return &menuv1.GetMenuVersionResponse{Version: c.version}, nil
+}
+
+func TestGetMenuIsCachedUntilTheMenuChanges(t *testing.T) {
+	menu := &countingMenuClient{price: 2.5, version: "v1"}
+	menuCache := cache.NewMenuCache(cache.DefaultSize, time.Minute)
+	require.NoError(t, menuCache.Poll(context.Background(), menu))
+	h := NewHandlers(&grpc.ServiceClients{MenuClient: menu}, WithMenuCache(menuCache))
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/menu", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		h.GetMenu(rec, req)
+		return rec
+	}
+
+	first := get("")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, cache.CacheControl, first.Header().Get("Cache-Control"))
+	var items []map[string]any
+	require.NoError(t, json.NewDecoder(first.Body).Decode(&items))
+	assert.Equal(t, "Coffee", items[0]["name"])
+
+	// Repeat reads and revalidations are answered from the cache
+	assert.Equal(t, http.StatusOK, get("").Code)
+	assert.Equal(t, http.StatusNotModified, get(etag).Code)
+	assert.Equal(t, 1, menu.getMenus)
+
+	// menu-service reports a change: the next read goes to the backend
+	menu.price, menu.version = 2.75, "v2"
+	require.NoError(t, menuCache.Poll(context.Background(), menu))
+	changed := get(etag)
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.NotEqual(t, etag, changed.Header().Get("ETag"))
+	assert.Equal(t, 2, menu.getMenus)
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/douglasswm/student-cafe-common/health"
+)
+
+// ReadinessResponse reports the gateway's readiness and each backend's health
+type ReadinessResponse struct {
+	Status   string            `json:"status"`
+	Services map[string]string `json:"services"`
+}
+
+// Healthz reports that the gateway process is alive. It does not look at
+// the backends, so a backend outage never gets the gateway restarted.
+func (h *Handlers) Healthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// Readyz reports whether every backend is serving, checking them concurrently
+func (h *Handlers) Readyz(w http.ResponseWriter, r *http.Request) {
+	results := health.RunChecks(r.Context(), health.DefaultTimeout, h.clients.HealthChecks)
+
+	resp := ReadinessResponse{Status: "ready", Services: make(map[string]string, len(results))}
+	code := http.StatusOK
+	for name, err := range results {
+		if err != nil {
+			resp.Services[name] = err.Error()
+			resp.Status = "unavailable"
+			code = http.StatusServiceUnavailable
+			continue
+		}
+		resp.Services[name] = "SERVING"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(resp)
+}
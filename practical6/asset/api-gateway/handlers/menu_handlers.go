@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"strconv"
 
+	"api-gateway/cache"
+	"api-gateway/render"
+
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	"github.com/go-chi/chi/v5"
 )
@@ -14,33 +16,25 @@ import (
 // Translates HTTP request to gRPC CreateMenuItem call
 func (h *Handlers) CreateMenuItem(w http.ResponseWriter, r *http.Request) {
 	// Parse HTTP JSON request body
-	var req struct {
-		Name        string  `json:"name"`
-		Description string  `json:"description"`
-		Price       float64 `json:"price"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	var req menuv1.CreateMenuItemRequest
+	if err := render.Decode(r, &req); err != nil {
+		invalidBody(w, r, err)
 		return
 	}
 
 	// Call gRPC service
-	resp, err := h.clients.MenuClient.CreateMenuItem(context.Background(), &menuv1.CreateMenuItemRequest{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-	})
+	resp, err := h.clients.MenuClient.CreateMenuItem(r.Context(), &req)
 
 	if err != nil {
-		handleGRPCError(w, err)
+		handleGRPCError(w, r, err)
 		return
 	}
 
+	// Don't serve the menu from before the new item
+	h.menuCache.Invalidate()
+
 	// Return HTTP JSON response
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(resp.MenuItem)
+	render.Message(w, r, http.StatusCreated, resp.MenuItem)
 }
 
 // GetMenuItem handles GET /api/menu/{id}
@@ -50,37 +44,47 @@ func (h *Handlers) GetMenuItem(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		http.Error(w, "invalid menu item ID", http.StatusBadRequest)
+		invalidID(w, r, "menu item")
 		return
 	}
 
-	// Call gRPC service
-	resp, err := h.clients.MenuClient.GetMenuItem(context.Background(), &menuv1.GetMenuItemRequest{
-		Id: uint32(id),
+	// Call gRPC service unless the item is cached
+	entry, err := h.menuCache.Fetch(r.Context(), "item:"+strconv.FormatUint(id, 10), func(ctx context.Context) ([]byte, error) {
+		resp, err := h.clients.MenuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{
+			Id: uint32(id),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return render.Marshal(resp.MenuItem)
 	})
 
 	if err != nil {
-		handleGRPCError(w, err)
+		handleGRPCError(w, r, err)
 		return
 	}
 
-	// Return HTTP JSON response
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.MenuItem)
+	// Return HTTP JSON response, or 304 if the client's copy is current
+	cache.Write(w, r, entry)
 }
 
 // GetMenu handles GET /api/menu
 // Translates HTTP request to gRPC GetMenu call
 func (h *Handlers) GetMenu(w http.ResponseWriter, r *http.Request) {
-	// Call gRPC service
-	resp, err := h.clients.MenuClient.GetMenu(context.Background(), &menuv1.GetMenuRequest{})
+	// Call gRPC service unless the menu is cached
+	entry, err := h.menuCache.Fetch(r.Context(), "menu", func(ctx context.Context) ([]byte, error) {
+		resp, err := h.clients.MenuClient.GetMenu(ctx, &menuv1.GetMenuRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return render.MarshalList(resp.MenuItems)
+	})
 
 	if err != nil {
-		handleGRPCError(w, err)
+		handleGRPCError(w, r, err)
 		return
 	}
 
-	// Return HTTP JSON response
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.MenuItems)
+	// Return HTTP JSON response, or 304 if the client's copy is current
+	cache.Write(w, r, entry)
 }
@@ -1,10 +1,13 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+
+	"api-gateway/render"
 
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	"github.com/go-chi/chi/v5"
@@ -14,43 +17,22 @@ import (
 // Translates HTTP request to gRPC CreateOrder call
 func (h *Handlers) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	// Parse HTTP JSON request body
-	var req struct {
-		UserID uint32 `json:"user_id"`
-		Items  []struct {
-			MenuItemID uint32 `json:"menu_item_id"`
-			Quantity   uint32 `json:"quantity"`
-		} `json:"items"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	var req orderv1.CreateOrderRequest
+	if err := render.Decode(r, &req); err != nil {
+		invalidBody(w, r, err)
 		return
 	}
 
-	// Convert HTTP items to gRPC OrderItemRequest protobuf messages
-	var items []*orderv1.OrderItemRequest
-	for _, item := range req.Items {
-		items = append(items, &orderv1.OrderItemRequest{
-			MenuItemId: item.MenuItemID,
-			Quantity:   int32(item.Quantity),
-		})
-	}
-
 	// Call gRPC service
-	resp, err := h.clients.OrderClient.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
-		UserId: req.UserID,
-		Items:  items,
-	})
+	resp, err := h.clients.OrderClient.CreateOrder(r.Context(), &req)
 
 	if err != nil {
-		handleGRPCError(w, err)
+		handleGRPCError(w, r, err)
 		return
 	}
 
 	// Return HTTP JSON response
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(resp.Order)
+	render.Message(w, r, http.StatusCreated, resp.Order)
 }
 
 // GetOrder handles GET /api/orders/{id}
@@ -60,37 +42,126 @@ func (h *Handlers) GetOrder(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		http.Error(w, "invalid order ID", http.StatusBadRequest)
+		invalidID(w, r, "order")
 		return
 	}
 
 	// Call gRPC service
-	resp, err := h.clients.OrderClient.GetOrder(context.Background(), &orderv1.GetOrderRequest{
+	resp, err := h.clients.OrderClient.GetOrder(r.Context(), &orderv1.GetOrderRequest{
 		Id: uint32(id),
 	})
 
 	if err != nil {
-		handleGRPCError(w, err)
+		handleGRPCError(w, r, err)
 		return
 	}
 
 	// Return HTTP JSON response
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.Order)
+	render.Message(w, r, http.StatusOK, resp.Order)
 }
 
 // GetOrders handles GET /api/orders
 // Translates HTTP request to gRPC GetOrders call
 func (h *Handlers) GetOrders(w http.ResponseWriter, r *http.Request) {
 	// Call gRPC service
-	resp, err := h.clients.OrderClient.GetOrders(context.Background(), &orderv1.GetOrdersRequest{})
+	resp, err := h.clients.OrderClient.GetOrders(r.Context(), &orderv1.GetOrdersRequest{})
 
 	if err != nil {
-		handleGRPCError(w, err)
+		handleGRPCError(w, r, err)
 		return
 	}
 
 	// Return HTTP JSON response
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.Orders)
+	render.List(w, r, http.StatusOK, resp.Orders)
+}
+
+// AmendOrder handles PATCH /api/orders/{id}
+// Translates HTTP request to gRPC AmendOrder call
+func (h *Handlers) AmendOrder(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		invalidID(w, r, "order")
+		return
+	}
+
+	// Parse HTTP JSON request body: the desired quantity per order line (0 removes it)
+	var req struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	changes := make([]*orderv1.OrderItemChange, len(req.Items))
+	for i, item := range req.Items {
+		changes[i] = &orderv1.OrderItemChange{}
+		if err := render.UnmarshalOptions.Unmarshal(item, changes[i]); err != nil {
+			invalidBody(w, r, fmt.Errorf("items[%d]: %w", i, err))
+			return
+		}
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.OrderClient.AmendOrder(r.Context(), &orderv1.AmendOrderRequest{
+		Id:      uint32(id),
+		Changes: changes,
+	})
+
+	if err != nil {
+		handleGRPCError(w, r, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	render.Message(w, r, http.StatusOK, resp.Order)
+}
+
+// receiptFormats maps the ?format= query value to the gRPC receipt format
+var receiptFormats = map[string]orderv1.ReceiptFormat{
+	"":     orderv1.ReceiptFormat_RECEIPT_FORMAT_TEXT,
+	"text": orderv1.ReceiptFormat_RECEIPT_FORMAT_TEXT,
+	"txt":  orderv1.ReceiptFormat_RECEIPT_FORMAT_TEXT,
+	"html": orderv1.ReceiptFormat_RECEIPT_FORMAT_HTML,
+	"pdf":  orderv1.ReceiptFormat_RECEIPT_FORMAT_PDF,
+}
+
+// GetOrderReceipt handles GET /api/orders/{id}/receipt?format=text|html|pdf
+// Translates HTTP request to gRPC GetOrderReceipt call
+func (h *Handlers) GetOrderReceipt(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		invalidID(w, r, "order")
+		return
+	}
+
+	format, ok := receiptFormats[strings.ToLower(r.URL.Query().Get("format"))]
+	if !ok {
+		render.BadRequest(w, r, "invalid receipt format", render.FieldViolation{Field: "format", Description: "must be text, html or pdf"})
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.OrderClient.GetOrderReceipt(r.Context(), &orderv1.GetOrderReceiptRequest{
+		Id:     uint32(id),
+		Format: format,
+	})
+
+	if err != nil {
+		handleGRPCError(w, r, err)
+		return
+	}
+
+	// Return the rendered receipt as-is
+	w.Header().Set("Content-Type", resp.ContentType)
+	disposition := "inline"
+	if format == orderv1.ReceiptFormat_RECEIPT_FORMAT_PDF {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("%s; filename=%q", disposition, resp.Filename))
+	w.Write(resp.Content)
 }
@@ -1,11 +1,11 @@
 package handlers
 
 import (
-	"context"
-	"encoding/json"
 	"net/http"
 	"strconv"
 
+	"api-gateway/render"
+
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
 	"github.com/go-chi/chi/v5"
 )
@@ -14,33 +14,22 @@ import (
 // Translates HTTP request to gRPC CreateUser call
 func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 	// Parse HTTP JSON request body
-	var req struct {
-		Name        string `json:"name"`
-		Email       string `json:"email"`
-		IsCafeOwner bool   `json:"is_cafe_owner"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	var req userv1.CreateUserRequest
+	if err := render.Decode(r, &req); err != nil {
+		invalidBody(w, r, err)
 		return
 	}
 
 	// Call gRPC service
-	resp, err := h.clients.UserClient.CreateUser(context.Background(), &userv1.CreateUserRequest{
-		Name:        req.Name,
-		Email:       req.Email,
-		IsCafeOwner: req.IsCafeOwner,
-	})
+	resp, err := h.clients.UserClient.CreateUser(r.Context(), &req)
 
 	if err != nil {
-		handleGRPCError(w, err)
+		handleGRPCError(w, r, err)
 		return
 	}
 
 	// Return HTTP JSON response
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(resp.User)
+	render.Message(w, r, http.StatusCreated, resp.User)
 }
 
 // GetUser handles GET /api/users/{id}
@@ -50,37 +39,35 @@ func (h *Handlers) GetUser(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		http.Error(w, "invalid user ID", http.StatusBadRequest)
+		invalidID(w, r, "user")
 		return
 	}
 
 	// Call gRPC service
-	resp, err := h.clients.UserClient.GetUser(context.Background(), &userv1.GetUserRequest{
+	resp, err := h.clients.UserClient.GetUser(r.Context(), &userv1.GetUserRequest{
 		Id: uint32(id),
 	})
 
 	if err != nil {
-		handleGRPCError(w, err)
+		handleGRPCError(w, r, err)
 		return
 	}
 
 	// Return HTTP JSON response
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.User)
+	render.Message(w, r, http.StatusOK, resp.User)
 }
 
 // GetUsers handles GET /api/users
 // Translates HTTP request to gRPC GetUsers call
 func (h *Handlers) GetUsers(w http.ResponseWriter, r *http.Request) {
 	// Call gRPC service
-	resp, err := h.clients.UserClient.GetUsers(context.Background(), &userv1.GetUsersRequest{})
+	resp, err := h.clients.UserClient.GetUsers(r.Context(), &userv1.GetUsersRequest{})
 
 	if err != nil {
-		handleGRPCError(w, err)
+		handleGRPCError(w, r, err)
 		return
 	}
 
 	// Return HTTP JSON response
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.Users)
+	render.List(w, r, http.StatusOK, resp.Users)
 }
@@ -1,49 +1,203 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"strings"
 
+	"api-gateway/cache"
 	"api-gateway/grpc"
 	"api-gateway/handlers"
+	"api-gateway/ratelimit"
+	"api-gateway/requestctx"
 
+	"github.com/douglasswm/student-cafe-common/config"
+	"github.com/douglasswm/student-cafe-common/discovery"
+	"github.com/douglasswm/student-cafe-common/lifecycle"
+	"github.com/douglasswm/student-cafe-common/logging"
+	"github.com/douglasswm/student-cafe-common/metrics"
+	"github.com/douglasswm/student-cafe-common/mtls"
+	"github.com/douglasswm/student-cafe-common/telemetry"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/riandyrn/otelchi"
 )
 
 func main() {
+	// Load configuration from env, an optional YAML file and flags
+	configOpts := config.Options{
+		Service: "api-gateway",
+		Defaults: config.Config{
+			HTTPAddr:         ":8081",
+			AdminPort:        "9100",
+			UserServiceAddr:  "user-service:9091",
+			MenuServiceAddr:  "menu-service:9092",
+			OrderServiceAddr: "order-service:9093",
+			MenuCacheTTL:     cache.DefaultTTL,
+		},
+		Required: []string{"http_addr", "admin_port", "user_service_addr", "menu_service_addr", "order_service_addr"},
+		Args:     os.Args[1:],
+	}
+	cfg, err := config.Load(configOpts)
+	if err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+
+	// JSON logs tagged with the service name
+	logger := logging.Setup(cfg.Logging("api-gateway"))
+	slog.Info("Configuration loaded", "config", cfg)
+
+	// Cancelled on SIGINT/SIGTERM to start a graceful shutdown
+	ctx, stop := lifecycle.SignalContext()
+	defer stop()
+
+	// Set up tracing before anything that makes calls
+	shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.ConfigFromEnv("api-gateway"))
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	// Present the gateway's certificate to the backends when mutual TLS is configured
+	creds, err := mtls.ClientCredentials(cfg.TLS())
+	if err != nil {
+		logging.Fatal("Failed to load TLS certificates", "error", err)
+	}
+
+	// Look up discovery:/// backend addresses in the registry, when configured
+	registry, err := discovery.New(cfg.Discovery())
+	if err != nil {
+		logging.Fatal("Failed to set up service discovery", "error", err)
+	}
+
 	// Initialize gRPC clients for all backend services
-	clients, err := grpc.NewServiceClients()
+	clients, err := grpc.NewServiceClients(cfg.UserServiceAddr, cfg.MenuServiceAddr, cfg.OrderServiceAddr, creds, discovery.DialOption(registry))
 	if err != nil {
-		log.Fatalf("Failed to create gRPC clients: %v", err)
+		logging.Fatal("Failed to create gRPC clients", "error", err)
+	}
+	slog.Info("gRPC clients initialized successfully")
+
+	// Cache menu reads until they expire or menu-service reports a change
+	var menuCache *cache.MenuCache
+	if cfg.MenuCacheTTL > 0 {
+		menuCache = cache.NewMenuCache(cache.DefaultSize, cfg.MenuCacheTTL)
+		go menuCache.Watch(ctx, clients.MenuClient, cache.DefaultPollInterval)
 	}
-	log.Println("gRPC clients initialized successfully")
 
 	// Create handlers with gRPC clients
-	h := handlers.NewHandlers(clients)
+	h := handlers.NewHandlers(clients, handlers.WithMenuCache(menuCache))
+
+	// Per-route request timeouts, e.g. "POST /api/orders=15s;5s"
+	timeouts, err := parseRouteTimeouts(cfg.RouteTimeouts)
+	if err != nil {
+		logging.Fatal("Invalid route_timeouts", "error", err)
+	}
+	routeTimeouts := requestctx.NewRouteTimeouts(timeouts)
+
+	// Per-route rate limits per client, e.g. "POST /api/orders=5/s:10",
+	// shared between replicas through Redis when REDIS_ADDR is set
+	limits, err := ratelimit.ParseLimits(cfg.RateLimits)
+	if err != nil {
+		logging.Fatal("Invalid rate_limits", "error", err)
+	}
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RedisAddr != "" {
+		redisClient := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
+		defer redisClient.Close()
+		store = ratelimit.NewRedisStore(redisClient, "cafe:ratelimit:")
+		slog.Info("Rate limits shared through Redis", "addr", cfg.RedisAddr)
+	}
+	limiter := ratelimit.NewLimiter(store, limits)
+	limiter.StoreAPIKeys(strings.Split(cfg.RateLimitAPIKeys, ","))
+
+	// Apply log level, timeout and rate limit changes without a restart
+	reloader := config.NewReloader(configOpts, cfg)
+	reloader.OnChange(config.ApplyLogLevel)
+	reloader.OnChange(func(cfg config.Config) {
+		timeouts, err := parseRouteTimeouts(cfg.RouteTimeouts)
+		if err != nil {
+			slog.Error("Ignoring invalid route_timeouts", "error", err)
+			return
+		}
+		routeTimeouts.Store(timeouts)
+	})
+	reloader.OnChange(func(cfg config.Config) {
+		limits, err := ratelimit.ParseLimits(cfg.RateLimits)
+		if err != nil {
+			slog.Error("Ignoring invalid rate_limits", "error", err)
+			return
+		}
+		limiter.Store(limits)
+		limiter.StoreAPIKeys(strings.Split(cfg.RateLimitAPIKeys, ","))
+	})
+	go reloader.Watch(ctx, config.DefaultWatchInterval)
+
+	// Serve metrics on a separate admin port
+	adminServer, err := metrics.ServeAdmin(":"+cfg.AdminPort, metrics.NewAdminMux(prometheus.DefaultGatherer))
+	if err != nil {
+		logging.Fatal("Failed to start admin server", "port", cfg.AdminPort, "error", err)
+	}
+	httpMetrics := metrics.NewHTTPMetrics(prometheus.DefaultRegisterer)
 
 	// Setup HTTP router
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	// Record metrics outside Recoverer so panics are counted as 500s
+	r.Use(httpMetrics.Middleware(func(r *http.Request) string {
+		return chi.RouteContext(r.Context()).RoutePattern()
+	}))
 	r.Use(middleware.Recoverer)
+	// Start a server span per request, named after the matched route
+	r.Use(otelchi.Middleware("api-gateway", otelchi.WithChiRoutes(r)))
+	r.Use(requestctx.Middleware)
+	// Log each request with its request ID and trace ID
+	r.Use(logging.Middleware(logger, func(r *http.Request) string {
+		return chi.RouteContext(r.Context()).RoutePattern()
+	}))
+
+	// route registers a handler behind its rate limit and bounded by its
+	// configured timeout
+	registerRoutes(r, h, func(method, pattern string, handler http.HandlerFunc) {
+		r.With(limiter.Middleware(method, pattern), routeTimeouts.Middleware(method, pattern)).Method(method, pattern, handler)
+	})
+
+	srv := &http.Server{Addr: cfg.HTTPAddr, Handler: r}
+	slog.Info("API Gateway starting (HTTP→gRPC translation layer)", "addr", srv.Addr)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.Fatal("Failed to start server", "error", err)
+		}
+	}()
 
-	// User routes - HTTP to gRPC translation
-	r.Post("/api/users", h.CreateUser)
-	r.Get("/api/users/{id}", h.GetUser)
-	r.Get("/api/users", h.GetUsers)
+	<-ctx.Done()
+	slog.Info("Shutting down API Gateway")
 
-	// Menu routes - HTTP to gRPC translation
-	r.Post("/api/menu", h.CreateMenuItem)
-	r.Get("/api/menu/{id}", h.GetMenuItem)
-	r.Get("/api/menu", h.GetMenu)
+	// Stop accepting connections and wait for in-flight requests to finish
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), reloader.Current().ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("In-flight requests did not finish in time", "error", err)
+	}
 
-	// Order routes - HTTP to gRPC translation
-	r.Post("/api/orders", h.CreateOrder)
-	r.Get("/api/orders/{id}", h.GetOrder)
-	r.Get("/api/orders", h.GetOrders)
+	adminServer.Close()
+	clients.Close()
+	slog.Info("API Gateway stopped")
+}
 
-	log.Println("API Gateway starting on :8081 (HTTP→gRPC translation layer)")
-	if err := http.ListenAndServe(":8081", r); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+// parseRouteTimeouts reads the route_timeouts spec, rejecting timeouts for
+// routes the gateway doesn't serve, which would otherwise never apply
+func parseRouteTimeouts(spec string) (requestctx.Timeouts, error) {
+	timeouts, err := requestctx.ParseTimeouts(spec, requestctx.DefaultTimeout)
+	if err != nil {
+		return requestctx.Timeouts{}, err
+	}
+	if unknown := timeouts.UnknownRoutes(routeKeys()); len(unknown) > 0 {
+		return requestctx.Timeouts{}, fmt.Errorf("no such route: %s", strings.Join(unknown, ", "))
 	}
+	return timeouts, nil
 }
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"api-gateway/grpc"
+	"api-gateway/handlers"
+	"api-gateway/openapi"
+	"api-gateway/ratelimit"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoutesMatchOpenAPISpec fails when a route is registered without being
+// described in the OpenAPI document, or described without being registered
+func TestRoutesMatchOpenAPISpec(t *testing.T) {
+	r := chi.NewRouter()
+	registerRoutes(r, handlers.NewHandlers(&grpc.ServiceClients{}), func(method, pattern string, handler http.HandlerFunc) {
+		r.Method(method, pattern, handler)
+	})
+
+	var registered []string
+	require.NoError(t, chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		registered = append(registered, method+" "+route)
+		return nil
+	}))
+
+	spec := openapi.Spec()
+	var documented []string
+	pathParam := regexp.MustCompile(`\{(\w+)\}`)
+	for path, item := range spec.Paths {
+		for method, op := range item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+
+			// Every path parameter is declared
+			for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+				found := false
+				for _, p := range op.Parameters {
+					found = found || (p.In == "path" && p.Name == m[1])
+				}
+				assert.True(t, found, "%s %s does not declare path parameter %q", method, path, m[1])
+			}
+		}
+	}
+
+	sort.Strings(registered)
+	sort.Strings(documented)
+	assert.Equal(t, registered, documented, "routes in routes.go and openapi.Spec have drifted apart")
+}
+
+func TestParseRouteTimeouts(t *testing.T) {
+	timeouts, err := parseRouteTimeouts("10s;GET /api/orders/{id}/receipt=20s")
+	require.NoError(t, err)
+	assert.Equal(t, 20*time.Second, timeouts.For(http.MethodGet, "/api/orders/{id}/receipt"))
+
+	_, err = parseRouteTimeouts("GET /api/orders/{orderId}/receipt=20s;POST /api/order=5s")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GET /api/orders/{orderId}/receipt, POST /api/order")
+}
+
+func TestProbesAreNotRateLimited(t *testing.T) {
+	limits, err := ratelimit.ParseLimits("1/h")
+	require.NoError(t, err)
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), limits)
+	r := chi.NewRouter()
+	registerRoutes(r, handlers.NewHandlers(&grpc.ServiceClients{}), func(method, pattern string, handler http.HandlerFunc) {
+		r.With(limiter.Middleware(method, pattern)).Method(method, pattern, handler)
+	})
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		for i := 0; i < 3; i++ {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusOK, rec.Code, path)
+			assert.Empty(t, rec.Header().Get(ratelimit.HeaderLimit), path)
+		}
+	}
+
+	// API routes are limited by the default
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/menu/abc", nil))
+	assert.Equal(t, "1", rec.Header().Get(ratelimit.HeaderLimit))
+}
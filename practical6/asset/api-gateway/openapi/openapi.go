@@ -0,0 +1,332 @@
+// Package openapi describes the gateway's REST API as an OpenAPI 3
+// document. Request and response schemas are generated from the menuv1,
+// orderv1 and userv1 messages the handlers decode into and encode.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"api-gateway/render"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Version is the version of the REST API described
+const Version = "1.0.0"
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations on one path, keyed by lower-case method
+type PathItem map[string]*Operation
+
+// Operation describes one route
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Required    bool    `json:"required,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes an operation's request body
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes one response status
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType gives the schema of one content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the reusable schemas
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Spec builds the document describing every route the gateway serves
+func Spec() *Document {
+	s := schemas{}
+	msg := func(m proto.Message) *Schema { return s.message(m.ProtoReflect().Descriptor()) }
+	field := func(m proto.Message, name protoreflect.Name) *Schema {
+		return s.field(m.ProtoReflect().Descriptor().Fields().ByName(name))
+	}
+
+	user := msg(&userv1.User{})
+	menuItem := msg(&menuv1.MenuItem{})
+	order := msg(&orderv1.Order{})
+
+	s["Readiness"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"status":   {Type: "string", Enum: []string{"ready", "unavailable"}},
+			"services": {Type: "object", AdditionalProperties: &Schema{Type: "string"}, Description: "SERVING, or why the service is not ready"},
+		},
+	}
+	s["Error"] = &Schema{
+		Type:     "object",
+		Required: []string{"error"},
+		Properties: map[string]*Schema{"error": {
+			Type:     "object",
+			Required: []string{"code", "message"},
+			Properties: map[string]*Schema{
+				"code":       {Type: "string", Description: "gRPC status code name, e.g. INVALID_ARGUMENT"},
+				"message":    {Type: "string"},
+				"request_id": {Type: "string", Description: "Matches the X-Request-ID response header"},
+				"field_violations": arrayOf(&Schema{
+					Type: "object",
+					Properties: map[string]*Schema{
+						"field":       {Type: "string"},
+						"description": {Type: "string"},
+					},
+				}),
+				"details": arrayOf(&Schema{Type: "object", Description: "gRPC status detail with its @type"}),
+			},
+		}},
+	}
+	s["AmendOrderRequest"] = &Schema{
+		Type:        "object",
+		Description: "The desired quantity per order line; 0 removes it. A line is order_item_id when set, otherwise the line with the same menu item, modifier options and bundle selections, which is added if the order lacks it",
+		Properties:  map[string]*Schema{"items": field(&orderv1.AmendOrderRequest{}, "changes")},
+	}
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Student Cafe API",
+			Version:     Version,
+			Description: "REST gateway in front of the user, menu and order gRPC services. Errors are returned as a JSON envelope.",
+		},
+		Paths: map[string]PathItem{
+			"/healthz": {
+				"get": {
+					OperationID: "healthz", Summary: "Liveness probe", Tags: []string{"health"},
+					Responses: map[string]Response{"200": jsonResponse("The gateway is running", &Schema{Type: "object", Properties: map[string]*Schema{"status": {Type: "string"}}})},
+				},
+			},
+			"/readyz": {
+				"get": {
+					OperationID: "readyz", Summary: "Readiness probe covering every backend", Tags: []string{"health"},
+					Responses: map[string]Response{
+						"200": jsonResponse("Every backend is serving", ref("Readiness")),
+						"503": jsonResponse("A backend is not serving", ref("Readiness")),
+					},
+				},
+			},
+			"/openapi.json": {
+				"get": {
+					OperationID: "openapi", Summary: "This document", Tags: []string{"docs"},
+					Responses: map[string]Response{"200": jsonResponse("OpenAPI 3 document", &Schema{Type: "object"})},
+				},
+			},
+			"/docs": {
+				"get": {
+					OperationID: "docs", Summary: "API reference rendered from this document", Tags: []string{"docs"},
+					Responses: map[string]Response{"200": {Description: "HTML page", Content: map[string]MediaType{"text/html": {Schema: &Schema{Type: "string"}}}}},
+				},
+			},
+			"/docs/redoc.standalone.js": {
+				"get": {
+					OperationID: "redoc", Summary: "Redoc " + RedocVersion + " bundle used by /docs", Tags: []string{"docs"},
+					Responses: map[string]Response{"200": {Description: "JavaScript bundle", Content: map[string]MediaType{"text/javascript": {Schema: &Schema{Type: "string"}}}}},
+				},
+			},
+
+			"/api/users": {
+				"post": api("createUser", "Create a user", "users").
+					body(msg(&userv1.CreateUserRequest{})).
+					respond(http.StatusCreated, "The created user", user).
+					errors(http.StatusBadRequest),
+				"get": api("listUsers", "List users", "users").
+					respond(http.StatusOK, "All users", arrayOf(user)),
+			},
+			"/api/users/{id}": {
+				"get": api("getUser", "Get a user", "users").
+					path("id", "User ID").
+					respond(http.StatusOK, "The user", user).
+					errors(http.StatusBadRequest, http.StatusNotFound),
+			},
+
+			"/api/menu": {
+				"post": api("createMenuItem", "Create a menu item", "menu").
+					body(msg(&menuv1.CreateMenuItemRequest{})).
+					respond(http.StatusCreated, "The created menu item; IDs of groups, options and slots are assigned by the service", menuItem).
+					errors(http.StatusBadRequest),
+				"get": api("getMenu", "List the menu", "menu").
+					respond(http.StatusOK, "Every menu item", arrayOf(menuItem)).
+					notModified(),
+			},
+			"/api/menu/{id}": {
+				"get": api("getMenuItem", "Get a menu item", "menu").
+					path("id", "Menu item ID").
+					respond(http.StatusOK, "The menu item", menuItem).
+					notModified().
+					errors(http.StatusBadRequest, http.StatusNotFound),
+			},
+
+			"/api/orders": {
+				"post": api("createOrder", "Place an order", "orders").
+					body(msg(&orderv1.CreateOrderRequest{})).
+					respond(http.StatusCreated, "The created order", order).
+					errors(http.StatusBadRequest, http.StatusNotFound, http.StatusPreconditionFailed),
+				"get": api("listOrders", "List orders", "orders").
+					respond(http.StatusOK, "All orders", arrayOf(order)),
+			},
+			"/api/orders/{id}": {
+				"get": api("getOrder", "Get an order", "orders").
+					path("id", "Order ID").
+					respond(http.StatusOK, "The order", order).
+					errors(http.StatusBadRequest, http.StatusNotFound),
+				"patch": api("amendOrder", "Change item quantities on a pending order", "orders").
+					path("id", "Order ID").
+					body(ref("AmendOrderRequest")).
+					respond(http.StatusOK, "The amended order", order).
+					errors(http.StatusBadRequest, http.StatusNotFound, http.StatusPreconditionFailed),
+			},
+			"/api/orders/{id}/receipt": {
+				"get": receipt(api("getOrderReceipt", "Render an order's receipt", "orders").
+					path("id", "Order ID").
+					errors(http.StatusBadRequest, http.StatusNotFound)),
+			},
+		},
+	}
+	doc.Components.Schemas = s
+	return doc
+}
+
+// api starts an operation on a rate-limited, time-bounded /api route
+func api(id, summary, tag string) *Operation {
+	op := &Operation{OperationID: id, Summary: summary, Tags: []string{tag}, Responses: map[string]Response{}}
+	return op.errors(http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusGatewayTimeout)
+}
+
+func (op *Operation) path(name, description string) *Operation {
+	op.Parameters = append(op.Parameters, Parameter{
+		Name: name, In: "path", Required: true, Description: description,
+		Schema: &Schema{Type: "integer", Format: "int64", Minimum: &zero},
+	})
+	return op
+}
+
+func (op *Operation) body(schema *Schema) *Operation {
+	op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{"application/json": {Schema: schema}}}
+	return op
+}
+
+func (op *Operation) respond(code int, description string, schema *Schema) *Operation {
+	op.Responses[strconv.Itoa(code)] = jsonResponse(description, schema)
+	return op
+}
+
+// notModified documents the ETag revalidation of cached menu reads
+func (op *Operation) notModified() *Operation {
+	op.Parameters = append(op.Parameters, Parameter{
+		Name: "If-None-Match", In: "header", Description: "ETag of a copy the client already has",
+		Schema: &Schema{Type: "string"},
+	})
+	op.Responses["304"] = Response{Description: "The client's copy (named by If-None-Match) is current"}
+	return op
+}
+
+func (op *Operation) errors(codes ...int) *Operation {
+	for _, code := range codes {
+		op.Responses[strconv.Itoa(code)] = jsonResponse(http.StatusText(code), ref("Error"))
+	}
+	return op
+}
+
+// receipt adds the receipt format parameter and its per-format content
+func receipt(op *Operation) *Operation {
+	op.Parameters = append(op.Parameters, Parameter{
+		Name: "format", In: "query", Description: "Receipt format (default text)",
+		Schema: &Schema{Type: "string", Enum: []string{"text", "txt", "html", "pdf"}},
+	})
+	op.Responses["200"] = Response{
+		Description: "The rendered receipt; PDFs are sent as an attachment",
+		Content: map[string]MediaType{
+			"text/plain":      {Schema: &Schema{Type: "string"}},
+			"text/html":       {Schema: &Schema{Type: "string"}},
+			"application/pdf": {Schema: &Schema{Type: "string", Format: "binary"}},
+		},
+	}
+	return op
+}
+
+func jsonResponse(description string, schema *Schema) Response {
+	return Response{Description: description, Content: map[string]MediaType{"application/json": {Schema: schema}}}
+}
+
+var encoded = sync.OnceValues(func() ([]byte, error) {
+	return json.MarshalIndent(Spec(), "", "  ")
+})
+
+// Handler serves the document as JSON
+func Handler(w http.ResponseWriter, r *http.Request) {
+	body, err := encoded()
+	if err != nil {
+		render.GRPCError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+//go:embed docs.html
+var docsPage []byte
+
+// Docs serves an HTML page rendering /openapi.json
+func Docs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(docsPage)
+}
+
+// RedocVersion is the Redoc release embedded in the gateway
+const RedocVersion = "2.1.5"
+
+//go:generate curl -fsSL -o redoc.standalone.js https://cdn.jsdelivr.net/npm/redoc@2.1.5/bundles/redoc.standalone.js
+//go:embed redoc.standalone.js
+var redocBundle []byte
+
+// Redoc serves the embedded Redoc bundle the docs page renders with
+func Redoc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Write(redocBundle)
+}
@@ -0,0 +1,193 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api-gateway/render"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// populate sets every field of m, recursing into messages
+func populate(m protoreflect.Message, depth int) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		switch {
+		case fd.IsMap():
+			continue
+		case fd.Kind() == protoreflect.MessageKind:
+			if depth == 0 {
+				continue
+			}
+			if fd.IsList() {
+				populate(m.Mutable(fd).List().AppendMutable().Message(), depth-1)
+			} else {
+				populate(m.Mutable(fd).Message(), depth-1)
+			}
+		case fd.IsList():
+			m.Mutable(fd).List().Append(scalar(fd))
+		default:
+			m.Set(fd, scalar(fd))
+		}
+	}
+}
+
+func scalar(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString("x")
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte("x"))
+	case protoreflect.EnumKind:
+		return protoreflect.ValueOfEnum(1)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(1.5)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(1.5)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(1)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(1)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(1)
+	default:
+		return protoreflect.ValueOfUint64(1)
+	}
+}
+
+// conforms checks that value, decoded from JSON, matches schema: every key
+// is a documented property and every value has the documented type
+func conforms(t *testing.T, doc *Document, schema *Schema, value any, at string) {
+	t.Helper()
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := doc.Components.Schemas[name]
+		require.True(t, ok, "%s: unresolved reference %s", at, schema.Ref)
+		schema = resolved
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		require.Equal(t, "object", schema.Type, at)
+		if schema.Properties == nil && schema.AdditionalProperties == nil {
+			return // free-form object
+		}
+		for key, inner := range v {
+			prop, ok := schema.Properties[key]
+			if schema.AdditionalProperties != nil {
+				prop, ok = schema.AdditionalProperties, true
+			}
+			if assert.True(t, ok, "%s: %q is not in the schema", at, key) {
+				conforms(t, doc, prop, inner, at+"."+key)
+			}
+		}
+	case []any:
+		require.Equal(t, "array", schema.Type, at)
+		for _, inner := range v {
+			conforms(t, doc, schema.Items, inner, at+"[]")
+		}
+	case string:
+		assert.Equal(t, "string", schema.Type, at)
+	case bool:
+		assert.Equal(t, "boolean", schema.Type, at)
+	case float64:
+		assert.Contains(t, []string{"integer", "number"}, schema.Type, at)
+	}
+}
+
+// TestSchemasMatchEncodedMessages encodes fully populated messages the way
+// the handlers do and checks the output against the generated schemas
+func TestSchemasMatchEncodedMessages(t *testing.T) {
+	doc := Spec()
+
+	for _, m := range []proto.Message{&userv1.User{}, &menuv1.MenuItem{}, &orderv1.Order{}, &orderv1.CreateOrderRequest{}, &menuv1.CreateMenuItemRequest{}} {
+		name := string(m.ProtoReflect().Descriptor().FullName())
+		t.Run(name, func(t *testing.T) {
+			populate(m.ProtoReflect(), 4)
+			body, err := render.Marshal(m)
+			require.NoError(t, err)
+			var decoded any
+			require.NoError(t, json.Unmarshal(body, &decoded))
+
+			conforms(t, doc, ref(name), decoded, name)
+
+			// And every documented property appears in the output
+			for key := range doc.Components.Schemas[name].Properties {
+				assert.Contains(t, decoded, key, name)
+			}
+		})
+	}
+}
+
+func TestErrorsMatchSchema(t *testing.T) {
+	rec := httptest.NewRecorder()
+	render.BadRequest(rec, httptest.NewRequest(http.MethodGet, "/", nil), "invalid order ID",
+		render.FieldViolation{Field: "id", Description: "must be a positive integer"})
+
+	var decoded any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+	conforms(t, Spec(), ref("Error"), decoded, "Error")
+}
+
+func TestEveryReferenceResolves(t *testing.T) {
+	doc := Spec()
+	body, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if r, ok := v["$ref"].(string); ok {
+				_, found := doc.Components.Schemas[strings.TrimPrefix(r, "#/components/schemas/")]
+				assert.True(t, found, "unresolved reference %s", r)
+			}
+			for _, inner := range v {
+				walk(inner)
+			}
+		case []any:
+			for _, inner := range v {
+				walk(inner)
+			}
+		}
+	}
+	var decoded any
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	walk(decoded)
+}
+
+func TestHandlers(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Handler(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var doc Document
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/api/orders")
+
+	rec = httptest.NewRecorder()
+	Docs(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `spec-url="/openapi.json"`)
+	assert.NotContains(t, rec.Body.String(), "https://", "the docs page must not load from a CDN")
+
+	rec = httptest.NewRecorder()
+	Redoc(rec, httptest.NewRequest(http.MethodGet, "/docs/redoc.standalone.js", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/javascript; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.NotEmpty(t, rec.Body.Bytes())
+}
@@ -0,0 +1,9 @@
+// Placeholder for the Redoc 2.1.5 standalone bundle. Replace it with the
+// real bundle by running "go generate ./openapi" in api-gateway; the
+// Docker build does this automatically.
+document.querySelectorAll("redoc").forEach(function (el) {
+  el.outerHTML = '<p style="font-family: sans-serif; margin: 2em">' +
+    'The Redoc bundle has not been vendored into this build. Run ' +
+    '<code>go generate ./openapi</code> in api-gateway, or read the raw ' +
+    '<a href="/openapi.json">OpenAPI document</a>.</p>';
+});
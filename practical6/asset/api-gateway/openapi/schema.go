@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Schema is an OpenAPI schema object (the subset the gateway needs)
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// ref points at a schema under #/components/schemas
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// arrayOf is a JSON array of items
+func arrayOf(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
+// schemas collects component schemas generated from proto messages
+type schemas map[string]*Schema
+
+// message adds the schema for md, and every message it refers to, and
+// returns a reference to it. Field names and types follow the proto JSON
+// mapping the gateway uses (see render.MarshalOptions): proto field names,
+// strings for 64-bit integers, enum value names and base64 for bytes.
+func (s schemas) message(md protoreflect.MessageDescriptor) *Schema {
+	name := string(md.FullName())
+	if _, ok := s[name]; ok {
+		return ref(name)
+	}
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	s[name] = schema // registered first so recursive messages terminate
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		schema.Properties[string(fd.Name())] = s.field(fd)
+	}
+	return ref(name)
+}
+
+// field returns the schema of a field, including repetition
+func (s schemas) field(fd protoreflect.FieldDescriptor) *Schema {
+	switch {
+	case fd.IsMap():
+		return &Schema{Type: "object", AdditionalProperties: s.value(fd.MapValue())}
+	case fd.IsList():
+		return arrayOf(s.value(fd))
+	default:
+		return s.value(fd)
+	}
+}
+
+var zero = 0.0
+
+// value returns the schema of a single field value
+func (s schemas) value(fd protoreflect.FieldDescriptor) *Schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer", Format: "int64", Minimum: &zero}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &Schema{Type: "string", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Type: "string", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &Schema{Type: "number", Format: "double"}
+	case protoreflect.StringKind:
+		return &Schema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		return &Schema{Type: "string", Enum: enumNames(fd.Enum())}
+	default:
+		return s.message(fd.Message())
+	}
+}
+
+// enumNames lists an enum's value names
+func enumNames(ed protoreflect.EnumDescriptor) []string {
+	values := ed.Values()
+	names := make([]string, values.Len())
+	for i := range names {
+		names[i] = string(values.Get(i).Name())
+	}
+	return names
+}
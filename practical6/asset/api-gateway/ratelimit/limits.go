@@ -0,0 +1,153 @@
+// Package ratelimit enforces per-route token-bucket rate limits in the
+// gateway. Each client (configured API key or IP
+// address) gets its own bucket per route; bucket state lives in a Store so
+// it can be kept in memory or shared between gateway replicas through Redis.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit lets a client make Rate requests per second on average, with
+// bursts of up to Burst requests. The zero Limit means unlimited.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether l imposes no limit
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// ParseLimit reads a limit in the form "N/unit" or "N/unit:burst", where
+// unit is s, m or h, e.g. "5/s:10" or "100/m". The burst defaults to N.
+func ParseLimit(s string) (Limit, error) {
+	s = strings.TrimSpace(s)
+	rate, burst, hasBurst := strings.Cut(s, ":")
+	count, unit, ok := strings.Cut(rate, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected \"N/unit[:burst]\"", s)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || n <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: count must be a positive integer", s)
+	}
+	var per time.Duration
+	switch strings.TrimSpace(unit) {
+	case "s":
+		per = time.Second
+	case "m":
+		per = time.Minute
+	case "h":
+		per = time.Hour
+	default:
+		return Limit{}, fmt.Errorf("invalid rate limit %q: unit must be s, m or h", s)
+	}
+
+	l := Limit{Rate: float64(n) / per.Seconds(), Burst: n}
+	if hasBurst {
+		b, err := strconv.Atoi(strings.TrimSpace(burst))
+		if err != nil || b <= 0 {
+			return Limit{}, fmt.Errorf("invalid rate limit %q: burst must be a positive integer", s)
+		}
+		l.Burst = b
+	}
+	return l, nil
+}
+
+// Limits holds the default limit and per-route overrides keyed by
+// "METHOD /pattern", e.g. "POST /api/orders"
+type Limits struct {
+	Default Limit
+	Routes  map[string]Limit
+}
+
+// ParseLimits reads route limits in the form
+// "POST /api/orders=5/s:10;GET /api/menu=50/s". A bare limit without a
+// route sets the default; "none" lifts the limit for a route.
+func ParseLimits(spec string) (Limits, error) {
+	l := Limits{Routes: make(map[string]Limit)}
+
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			route, value = "", entry
+		}
+		var limit Limit
+		if strings.TrimSpace(value) != "none" {
+			var err error
+			if limit, err = ParseLimit(value); err != nil {
+				return Limits{}, err
+			}
+		}
+
+		route = strings.TrimSpace(route)
+		if route == "" {
+			l.Default = limit
+			continue
+		}
+		method, pattern, ok := strings.Cut(route, " ")
+		if !ok || !strings.HasPrefix(strings.TrimSpace(pattern), "/") {
+			return Limits{}, fmt.Errorf("invalid route %q: expected \"METHOD /pattern\"", route)
+		}
+		l.Routes[strings.ToUpper(method)+" "+strings.TrimSpace(pattern)] = limit
+	}
+
+	return l, nil
+}
+
+// For returns the limit for a route
+func (l Limits) For(method, pattern string) Limit {
+	if limit, ok := l.Routes[method+" "+pattern]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	// Allowed is false when the bucket was empty
+	Allowed bool
+	// Remaining is the number of whole tokens left
+	Remaining int
+	// RetryAfter is how long until the next token, when not allowed
+	RetryAfter time.Duration
+	// ResetAfter is how long until the bucket is full again
+	ResetAfter time.Duration
+}
+
+// refill returns the tokens in a bucket holding tokens elapsed ago
+func refill(limit Limit, tokens float64, elapsed time.Duration) float64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+}
+
+// result describes a bucket left holding tokens after a take
+func result(limit Limit, tokens float64, allowed bool) Result {
+	r := Result{
+		Allowed:    allowed,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: seconds((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+	if !allowed {
+		r.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+	return r
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"api-gateway/render"
+)
+
+// Response headers describing the client's limit on the route, following
+// the IETF RateLimit header fields draft
+const (
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+	HeaderPolicy    = "RateLimit-Policy"
+)
+
+// HeaderAPIKey carries the client's API key
+const HeaderAPIKey = "X-API-Key"
+
+// ClientKey identifies who is making the request: the API key if it is one
+// of the configured keys, else the address of the immediate peer. Unknown
+// keys are ignored, so a client can't get a fresh bucket by sending a new
+// key with each request.
+func (l *Limiter) ClientKey(r *http.Request) string {
+	if key := r.Header.Get(HeaderAPIKey); key != "" {
+		if hash := hashKey(key); (*l.apiKeys.Load())[hash] {
+			return "key:" + hash
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// hashKey shortens an API key to a hash, so raw keys are never stored
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Limiter applies the current Limits to requests, keeping buckets in a
+// Store. The limits and API keys can be swapped while the gateway is
+// serving, e.g. on config reload.
+type Limiter struct {
+	buckets Store
+	current atomic.Pointer[Limits]
+	apiKeys atomic.Pointer[map[string]bool]
+}
+
+// NewLimiter enforces limits with buckets kept in store
+func NewLimiter(store Store, limits Limits) *Limiter {
+	l := &Limiter{buckets: store}
+	l.Store(limits)
+	l.StoreAPIKeys(nil)
+	return l
+}
+
+// StoreAPIKeys replaces the API keys that get a bucket of their own
+func (l *Limiter) StoreAPIKeys(keys []string) {
+	hashes := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			hashes[hashKey(key)] = true
+		}
+	}
+	l.apiKeys.Store(&hashes)
+}
+
+// Store replaces the limits used for new requests
+func (l *Limiter) Store(limits Limits) {
+	l.current.Store(&limits)
+}
+
+// For returns the current limit for a route
+func (l *Limiter) For(method, pattern string) Limit {
+	return l.current.Load().For(method, pattern)
+}
+
+// Middleware takes a token from the client's bucket for the route and
+// rejects the request with 429 when it is empty. If the store fails the
+// request is let through rather than taking the gateway down with it.
+func (l *Limiter) Middleware(method, pattern string) func(http.Handler) http.Handler {
+	route := method + " " + pattern
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit := l.For(method, pattern)
+			if limit.Unlimited() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			res, err := l.buckets.Take(r.Context(), route+"|"+l.ClientKey(r), limit)
+			if err != nil {
+				slog.WarnContext(r.Context(), "Rate limit check failed, allowing request", "route", route, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set(HeaderLimit, strconv.Itoa(limit.Burst))
+			h.Set(HeaderRemaining, strconv.Itoa(res.Remaining))
+			h.Set(HeaderReset, ceilSeconds(res.ResetAfter))
+			h.Set(HeaderPolicy, policy(limit))
+			if !res.Allowed {
+				h.Set("Retry-After", ceilSeconds(res.RetryAfter))
+				render.TooManyRequests(w, r, "rate limit exceeded")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// policy describes limit as a quota of Burst requests per window, the time
+// an empty bucket takes to refill
+func policy(limit Limit) string {
+	window := float64(limit.Burst) / limit.Rate
+	return strconv.Itoa(limit.Burst) + ";w=" + strconv.Itoa(int(math.Ceil(window)))
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,222 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits("100/m; post /api/orders=5/s:10 ;GET /api/menu=none")
+	require.NoError(t, err)
+
+	assert.Equal(t, Limit{Rate: 100.0 / 60, Burst: 100}, limits.Default)
+	assert.Equal(t, Limit{Rate: 5, Burst: 10}, limits.For(http.MethodPost, "/api/orders"))
+	assert.True(t, limits.For(http.MethodGet, "/api/menu").Unlimited())
+	assert.Equal(t, limits.Default, limits.For(http.MethodGet, "/api/users"))
+
+	empty, err := ParseLimits("")
+	require.NoError(t, err)
+	assert.True(t, empty.For(http.MethodPost, "/api/orders").Unlimited())
+
+	for _, spec := range []string{"5", "5/d", "0/s", "5/s:0", "POST=5/s", "POST api/orders=5/s"} {
+		_, err := ParseLimits(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+// clock is a manually advanced time source
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// testStores returns a MemoryStore and a RedisStore backed by miniredis,
+// both reading the same clock
+func testStores(t *testing.T, c *clock) map[string]Store {
+	t.Helper()
+
+	mem := NewMemoryStore()
+	mem.now = c.now
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	rs := NewRedisStore(client, "ratelimit:")
+	rs.now = c.now
+
+	return map[string]Store{"memory": mem, "redis": rs}
+}
+
+func TestStoresRefillTokenBuckets(t *testing.T) {
+	c := &clock{t: time.Unix(1_700_000_000, 0)}
+	limit := Limit{Rate: 2, Burst: 3}
+
+	for name, store := range testStores(t, c) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// The burst is available straight away
+			for want := 2; want >= 0; want-- {
+				res, err := store.Take(ctx, "client-a", limit)
+				require.NoError(t, err)
+				assert.True(t, res.Allowed)
+				assert.Equal(t, want, res.Remaining)
+			}
+
+			res, err := store.Take(ctx, "client-a", limit)
+			require.NoError(t, err)
+			assert.False(t, res.Allowed)
+			assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+			assert.Equal(t, 1500*time.Millisecond, res.ResetAfter)
+
+			// Other clients have their own bucket
+			res, err = store.Take(ctx, "client-b", limit)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed)
+
+			// One token comes back every 1/rate seconds
+			c.advance(500 * time.Millisecond)
+			res, err = store.Take(ctx, "client-a", limit)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed)
+			res, err = store.Take(ctx, "client-a", limit)
+			require.NoError(t, err)
+			assert.False(t, res.Allowed)
+
+			// The bucket never holds more than the burst
+			c.advance(time.Hour)
+			res, err = store.Take(ctx, "client-a", limit)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed)
+			assert.Equal(t, 2, res.Remaining)
+		})
+	}
+}
+
+func TestMemoryStoreDropsIdleBuckets(t *testing.T) {
+	c := &clock{t: time.Unix(1_700_000_000, 0)}
+	store := NewMemoryStore()
+	store.now = c.now
+
+	_, err := store.Take(context.Background(), "client-a", Limit{Rate: 1, Burst: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Len())
+
+	c.advance(sweepInterval)
+	_, err = store.Take(context.Background(), "client-b", Limit{Rate: 1, Burst: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestRedisStoreExpiresFullBuckets(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	_, err := NewRedisStore(client, "ratelimit:").Take(context.Background(), "client-a", Limit{Rate: 1, Burst: 5})
+	require.NoError(t, err)
+
+	require.True(t, mr.Exists("ratelimit:client-a"))
+	assert.Equal(t, 2*time.Second, mr.TTL("ratelimit:client-a"))
+}
+
+// failingStore fails every Take
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+func TestMiddleware(t *testing.T) {
+	limits, err := ParseLimits("POST /api/orders=1/m:2")
+	require.NoError(t, err)
+	limiter := NewLimiter(NewMemoryStore(), limits)
+	limiter.StoreAPIKeys([]string{"secret-key"})
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	orders := limiter.Middleware(http.MethodPost, "/api/orders")(ok)
+	send := func(handler http.Handler, configure func(*http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/orders", nil)
+		req.RemoteAddr = "203.0.113.7:5123"
+		if configure != nil {
+			configure(req)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("rejects once the bucket is empty", func(t *testing.T) {
+		rec := send(orders, nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get(HeaderLimit))
+		assert.Equal(t, "1", rec.Header().Get(HeaderRemaining))
+		assert.Equal(t, "60", rec.Header().Get(HeaderReset))
+		assert.Equal(t, "2;w=120", rec.Header().Get(HeaderPolicy))
+
+		assert.Equal(t, http.StatusOK, send(orders, nil).Code)
+
+		rec = send(orders, nil)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "0", rec.Header().Get(HeaderRemaining))
+		assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("keys by API key", func(t *testing.T) {
+		withKey := func(r *http.Request) { r.Header.Set(HeaderAPIKey, "secret-key") }
+		assert.Equal(t, http.StatusOK, send(orders, withKey).Code)
+		assert.Equal(t, http.StatusOK, send(orders, withKey).Code)
+		assert.Equal(t, http.StatusTooManyRequests, send(orders, withKey).Code)
+
+		// Made-up keys share the peer's exhausted bucket
+		withUnknownKey := func(r *http.Request) { r.Header.Set(HeaderAPIKey, "made-up-key") }
+		assert.Equal(t, http.StatusTooManyRequests, send(orders, withUnknownKey).Code)
+	})
+
+	t.Run("routes without a limit pass through", func(t *testing.T) {
+		menu := limiter.Middleware(http.MethodGet, "/api/menu")(ok)
+		rec := send(menu, nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(HeaderLimit))
+	})
+
+	t.Run("limits can be swapped", func(t *testing.T) {
+		limiter.Store(Limits{})
+		assert.Equal(t, http.StatusOK, send(orders, nil).Code)
+		limiter.Store(limits)
+	})
+
+	t.Run("a failing store lets requests through", func(t *testing.T) {
+		handler := NewLimiter(failingStore{}, limits).Middleware(http.MethodPost, "/api/orders")(ok)
+		assert.Equal(t, http.StatusOK, send(handler, nil).Code)
+	})
+}
+
+func TestClientKey(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), Limits{})
+	limiter.StoreAPIKeys([]string{"secret-key", " "})
+	req := httptest.NewRequest(http.MethodGet, "/api/menu", nil)
+	req.RemoteAddr = "203.0.113.7:5123"
+	assert.Equal(t, "ip:203.0.113.7", limiter.ClientKey(req))
+
+	req.Header.Set(HeaderAPIKey, "secret-key")
+	key := limiter.ClientKey(req)
+	assert.Regexp(t, `^key:[0-9a-f]{16}$`, key)
+	assert.NotContains(t, key, "secret-key")
+
+	// Unknown keys fall back to the peer address
+	req.Header.Set(HeaderAPIKey, "made-up-key")
+	assert.Equal(t, "ip:203.0.113.7", limiter.ClientKey(req))
+	limiter.StoreAPIKeys(nil)
+	req.Header.Set(HeaderAPIKey, "secret-key")
+	assert.Equal(t, "ip:203.0.113.7", limiter.ClientKey(req))
+}
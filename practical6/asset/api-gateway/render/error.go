@@ -0,0 +1,140 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"api-gateway/requestctx"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StatusClientClosedRequest is reported when the client went away before
+// the backend answered (the nginx convention; nothing reaches the client)
+const StatusClientClosedRequest = 499
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes what went wrong
+type ErrorBody struct {
+	// Code is the gRPC status code name, e.g. "INVALID_ARGUMENT"
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// RequestID matches the X-Request-ID response header and the logs
+	RequestID string `json:"request_id,omitempty"`
+	// FieldViolations lists invalid request fields, from BadRequest details
+	FieldViolations []FieldViolation `json:"field_violations,omitempty"`
+	// Details holds every gRPC status detail in the proto JSON mapping,
+	// each with its "@type"
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+// FieldViolation names an invalid request field and why it is invalid
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// Error writes st as an error envelope with the given HTTP status
+func Error(w http.ResponseWriter, r *http.Request, httpStatus int, st *status.Status) {
+	body := ErrorBody{
+		Code:      code.Code(st.Code()).String(),
+		Message:   st.Message(),
+		RequestID: requestctx.RequestID(r.Context()),
+	}
+	for _, detail := range st.Proto().GetDetails() {
+		encoded, err := MarshalOptions.Marshal(detail)
+		if err != nil {
+			continue // detail type not linked into the gateway
+		}
+		body.Details = append(body.Details, encoded)
+	}
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				body.FieldViolations = append(body.FieldViolations, FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(httpStatus)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: body})
+}
+
+// GRPCError writes err, returned by a backend call, as an error envelope
+// with the HTTP status matching its gRPC code
+func GRPCError(w http.ResponseWriter, r *http.Request, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		// Not a gRPC error, return generic internal server error
+		Error(w, r, http.StatusInternalServerError, status.New(codes.Internal, "internal server error"))
+		return
+	}
+	Error(w, r, HTTPStatus(st.Code()), st)
+}
+
+// HTTPStatus maps a gRPC status code to the HTTP status returned for it
+func HTTPStatus(c codes.Code) int {
+	switch c {
+	case codes.OK:
+		return http.StatusOK
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		return StatusClientClosedRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// BadRequest writes an INVALID_ARGUMENT error for a request the gateway
+// rejected itself, naming the offending fields
+func BadRequest(w http.ResponseWriter, r *http.Request, message string, violations ...FieldViolation) {
+	st := status.New(codes.InvalidArgument, message)
+	if len(violations) > 0 {
+		br := &errdetails.BadRequest{}
+		for _, v := range violations {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Description})
+		}
+		if withDetails, err := st.WithDetails(br); err == nil {
+			st = withDetails
+		}
+	}
+	Error(w, r, http.StatusBadRequest, st)
+}
+
+// TooManyRequests writes a RESOURCE_EXHAUSTED error
+func TooManyRequests(w http.ResponseWriter, r *http.Request, message string) {
+	Error(w, r, http.StatusTooManyRequests, status.New(codes.ResourceExhausted, message))
+}
+
+func internal(format string, args ...any) *status.Status {
+	return status.New(codes.Internal, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,87 @@
+// Package render reads and writes the gateway's JSON bodies. Proto messages
+// use the proto JSON mapping (protojson) with the proto field names, so
+// every client sees the same casing and zero values; errors use a JSON
+// envelope carrying the gRPC status.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxBodyBytes bounds the request bodies Decode reads
+const MaxBodyBytes = 1 << 20
+
+// MarshalOptions encode every response message: snake_case proto field
+// names, with zero values written rather than omitted
+var MarshalOptions = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// UnmarshalOptions decode request bodies. Both snake_case and lowerCamelCase
+// field names are accepted; unknown fields are ignored.
+var UnmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// Marshal encodes m as JSON followed by a newline
+func Marshal(m proto.Message) ([]byte, error) {
+	b, err := MarshalOptions.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// MarshalList encodes items as a JSON array followed by a newline
+func MarshalList[M proto.Message](items []M) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		b, err := MarshalOptions.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	buf.WriteString("]\n")
+	return buf.Bytes(), nil
+}
+
+// Message writes m as JSON with the given status
+func Message(w http.ResponseWriter, r *http.Request, status int, m proto.Message) {
+	body, err := Marshal(m)
+	writeJSON(w, r, status, body, err)
+}
+
+// List writes items as a JSON array with the given status
+func List[M proto.Message](w http.ResponseWriter, r *http.Request, status int, items []M) {
+	body, err := MarshalList(items)
+	writeJSON(w, r, status, body, err)
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, body []byte, err error) {
+	if err != nil {
+		Error(w, r, http.StatusInternalServerError, internal("failed to encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// Decode reads the request body as JSON into m
+func Decode(r *http.Request, m proto.Message) error {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, MaxBodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+	if err := UnmarshalOptions.Unmarshal(body, m); err != nil {
+		return err
+	}
+	return nil
+}
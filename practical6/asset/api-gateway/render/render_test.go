@@ -0,0 +1,128 @@
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api-gateway/requestctx"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMessageUsesProtoNamesAndEmitsZeroValues(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Message(rec, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusCreated, &userv1.User{Id: 7, Name: "Ada"})
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, false, got["is_cafe_owner"])
+	assert.Equal(t, "", got["email"])
+	assert.Equal(t, "Ada", got["name"])
+}
+
+func TestList(t *testing.T) {
+	rec := httptest.NewRecorder()
+	List(rec, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusOK, []*userv1.User{{Id: 1}, {Id: 2}})
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 2)
+	assert.EqualValues(t, 2, got[1]["id"])
+
+	empty, err := MarshalList([]*userv1.User{})
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", string(empty))
+}
+
+func TestDecodeAcceptsEitherCasing(t *testing.T) {
+	for _, body := range []string{
+		`{"user_id": 3, "items": [{"menu_item_id": 1, "quantity": 2}], "unknown": true}`,
+		`{"userId": 3, "items": [{"menuItemId": 1, "quantity": 2}]}`,
+	} {
+		var req orderv1.CreateOrderRequest
+		require.NoError(t, Decode(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), &req))
+		assert.EqualValues(t, 3, req.UserId)
+		require.Len(t, req.Items, 1)
+		assert.EqualValues(t, 2, req.Items[0].Quantity)
+	}
+
+	var req orderv1.CreateOrderRequest
+	assert.Error(t, Decode(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"user_id": "x"}`)), &req))
+}
+
+// decodeError reads an error envelope
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) ErrorBody {
+	t.Helper()
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Error
+}
+
+func TestGRPCErrorCarriesFieldViolations(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid order").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "items[0].quantity", Description: "must be greater than 0"},
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/orders", nil)
+	req = req.WithContext(requestctx.WithRequestID(req.Context(), "req-42"))
+	rec := httptest.NewRecorder()
+	GRPCError(rec, req, st.Err())
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	body := decodeError(t, rec)
+	assert.Equal(t, "INVALID_ARGUMENT", body.Code)
+	assert.Equal(t, "invalid order", body.Message)
+	assert.Equal(t, "req-42", body.RequestID)
+	assert.Equal(t, []FieldViolation{{Field: "items[0].quantity", Description: "must be greater than 0"}}, body.FieldViolations)
+	require.Len(t, body.Details, 1)
+	assert.Contains(t, string(body.Details[0]), `"@type":"type.googleapis.com/google.rpc.BadRequest"`)
+}
+
+func TestGRPCErrorStatusMapping(t *testing.T) {
+	for c, want := range map[codes.Code]int{
+		codes.NotFound:           http.StatusNotFound,
+		codes.FailedPrecondition: http.StatusPreconditionFailed,
+		codes.Unavailable:        http.StatusServiceUnavailable,
+		codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+		codes.Canceled:           StatusClientClosedRequest,
+		codes.Internal:           http.StatusInternalServerError,
+	} {
+		rec := httptest.NewRecorder()
+		GRPCError(rec, httptest.NewRequest(http.MethodGet, "/", nil), status.Error(c, "boom"))
+		assert.Equal(t, want, rec.Code, c.String())
+	}
+
+	// Errors that are not gRPC statuses don't leak their message
+	rec := httptest.NewRecorder()
+	GRPCError(rec, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("dial tcp: secret-host"))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	body := decodeError(t, rec)
+	assert.Equal(t, "INTERNAL", body.Code)
+	assert.NotContains(t, body.Message, "secret-host")
+}
+
+func TestBadRequest(t *testing.T) {
+	rec := httptest.NewRecorder()
+	BadRequest(rec, httptest.NewRequest(http.MethodGet, "/", nil), "invalid user ID", FieldViolation{Field: "id", Description: "must be a positive integer"})
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	body := decodeError(t, rec)
+	assert.Equal(t, "INVALID_ARGUMENT", body.Code)
+	assert.Equal(t, "invalid user ID", body.Message)
+	assert.Equal(t, []FieldViolation{{Field: "id", Description: "must be a positive integer"}}, body.FieldViolations)
+}
@@ -0,0 +1,97 @@
+// Package requestctx prepares the context of each HTTP request before it is
+// used for downstream gRPC calls: it assigns a request ID, applies a
+// per-route timeout and forwards caller details as outgoing gRPC metadata.
+package requestctx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/douglasswm/student-cafe-common/logging"
+	"google.golang.org/grpc/metadata"
+)
+
+// Header and metadata keys forwarded to the backend services
+const (
+	RequestIDHeader      = "X-Request-ID"
+	MetadataRequestID    = logging.MetadataRequestID
+	MetadataForwardedFor = "x-forwarded-for"
+	MetadataCallerAgent  = "x-caller-user-agent"
+)
+
+// RequestID returns the request ID stored in ctx, or "" if there is none
+func RequestID(ctx context.Context) string {
+	return logging.RequestID(ctx)
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID, which is
+// also added to every log line written with ctx
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return logging.WithRequestID(ctx, id)
+}
+
+// Middleware assigns a request ID (reusing a well-formed incoming
+// X-Request-ID), echoes it on the response and attaches the request ID and
+// caller details to the context as outgoing gRPC metadata
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := WithRequestID(r.Context(), id)
+		ctx = metadata.AppendToOutgoingContext(ctx, callerMetadata(r, id)...)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// callerMetadata lists the metadata key/value pairs describing the caller
+func callerMetadata(r *http.Request, id string) []string {
+	kv := []string{MetadataRequestID, id}
+
+	if ip := clientIP(r); ip != "" {
+		forwarded := ip
+		if prior := r.Header.Get("X-Forwarded-For"); prior != "" {
+			forwarded = prior + ", " + ip
+		}
+		kv = append(kv, MetadataForwardedFor, forwarded)
+	}
+	if ua := r.UserAgent(); ua != "" {
+		kv = append(kv, MetadataCallerAgent, ua)
+	}
+	return kv
+}
+
+// clientIP returns the address of the immediate peer
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// validRequestID accepts short IDs made of safe characters so that a client
+// cannot inject arbitrary data into logs or metadata
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	return strings.IndexFunc(id, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.')
+	}) < 0
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
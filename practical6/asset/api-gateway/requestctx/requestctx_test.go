@@ -0,0 +1,123 @@
+package requestctx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+// capture runs handler through Middleware and returns the context it saw
+func capture(t *testing.T, req *http.Request) (context.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+	var seen context.Context
+	rec := httptest.NewRecorder()
+	Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Context()
+	})).ServeHTTP(rec, req)
+	require.NotNil(t, seen)
+	return seen, rec
+}
+
+func TestMiddleware_GeneratesRequestID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/menu", nil)
+	req.RemoteAddr = "203.0.113.7:5123"
+	req.Header.Set("User-Agent", "cafe-app/1.0")
+
+	ctx, rec := capture(t, req)
+
+	id := RequestID(ctx)
+	assert.Len(t, id, 32)
+	assert.Equal(t, id, rec.Header().Get(RequestIDHeader))
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, []string{id}, md.Get(MetadataRequestID))
+	assert.Equal(t, []string{"203.0.113.7"}, md.Get(MetadataForwardedFor))
+	assert.Equal(t, []string{"cafe-app/1.0"}, md.Get(MetadataCallerAgent))
+}
+
+func TestMiddleware_ReusesIncomingRequestID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/menu", nil)
+	req.Header.Set(RequestIDHeader, "lb-1234_abc.def")
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	req.RemoteAddr = "10.0.0.2:4000"
+
+	ctx, rec := capture(t, req)
+
+	assert.Equal(t, "lb-1234_abc.def", RequestID(ctx))
+	assert.Equal(t, "lb-1234_abc.def", rec.Header().Get(RequestIDHeader))
+	md, _ := metadata.FromOutgoingContext(ctx)
+	assert.Equal(t, []string{"198.51.100.1, 10.0.0.2"}, md.Get(MetadataForwardedFor))
+}
+
+func TestMiddleware_ReplacesUnsafeRequestID(t *testing.T) {
+	for _, id := range []string{"has spaces", "line\nbreak", strings.Repeat("a", 129)} {
+		req := httptest.NewRequest(http.MethodGet, "/api/menu", nil)
+		req.Header.Set(RequestIDHeader, id)
+
+		ctx, _ := capture(t, req)
+
+		assert.NotEqual(t, id, RequestID(ctx))
+		assert.Len(t, RequestID(ctx), 32)
+	}
+}
+
+func TestParseTimeouts(t *testing.T) {
+	timeouts, err := ParseTimeouts("POST /api/orders=15s; 3s ;get /api/orders/{id}/receipt=20s", DefaultTimeout)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3*time.Second, timeouts.Default)
+	assert.Equal(t, 15*time.Second, timeouts.For(http.MethodPost, "/api/orders"))
+	assert.Equal(t, 20*time.Second, timeouts.For(http.MethodGet, "/api/orders/{id}/receipt"))
+	assert.Equal(t, 3*time.Second, timeouts.For(http.MethodGet, "/api/orders"))
+
+	empty, err := ParseTimeouts("", DefaultTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTimeout, empty.For(http.MethodGet, "/api/menu"))
+
+	for _, spec := range []string{"POST /api/orders=soon", "/api/orders=5s", "POST api/orders=5s", "-1s"} {
+		_, err := ParseTimeouts(spec, DefaultTimeout)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, hasDeadline = r.Context().Deadline()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+}
+
+func TestRouteTimeoutsCanBeSwapped(t *testing.T) {
+	initial, err := ParseTimeouts("POST /api/orders=15s", DefaultTimeout)
+	require.NoError(t, err)
+	rt := NewRouteTimeouts(initial)
+
+	var deadline time.Time
+	handler := rt.Middleware(http.MethodPost, "/api/orders")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, _ = r.Context().Deadline()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/orders", nil))
+	assert.WithinDuration(t, time.Now().Add(15*time.Second), deadline, 100*time.Millisecond)
+
+	// Requests after a reload use the new timeout
+	reloaded, err := ParseTimeouts("POST /api/orders=2s", DefaultTimeout)
+	require.NoError(t, err)
+	rt.Store(reloaded)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/orders", nil))
+	assert.WithinDuration(t, time.Now().Add(2*time.Second), deadline, 100*time.Millisecond)
+}
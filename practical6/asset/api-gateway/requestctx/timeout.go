@@ -0,0 +1,129 @@
+package requestctx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeout bounds a request when no route-specific timeout is configured
+const DefaultTimeout = 10 * time.Second
+
+// Timeouts holds the default request timeout and per-route overrides keyed
+// by "METHOD /pattern", e.g. "POST /api/orders"
+type Timeouts struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+// ParseTimeouts reads route timeouts in the form
+// "POST /api/orders=15s;GET /api/orders/{id}/receipt=20s". A bare duration
+// without a route sets the default.
+func ParseTimeouts(spec string, defaultTimeout time.Duration) (Timeouts, error) {
+	t := Timeouts{Default: defaultTimeout, Routes: make(map[string]time.Duration)}
+
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			route, value = "", entry
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return Timeouts{}, fmt.Errorf("invalid timeout %q", entry)
+		}
+
+		route = strings.TrimSpace(route)
+		if route == "" {
+			t.Default = d
+			continue
+		}
+		method, pattern, ok := strings.Cut(route, " ")
+		if !ok || !strings.HasPrefix(strings.TrimSpace(pattern), "/") {
+			return Timeouts{}, fmt.Errorf("invalid route %q: expected \"METHOD /pattern\"", route)
+		}
+		t.Routes[strings.ToUpper(method)+" "+strings.TrimSpace(pattern)] = d
+	}
+
+	return t, nil
+}
+
+// For returns the timeout for a route
+func (t Timeouts) For(method, pattern string) time.Duration {
+	if d, ok := t.Routes[method+" "+pattern]; ok {
+		return d
+	}
+	return t.Default
+}
+
+// UnknownRoutes returns the routes with a timeout that are not in known,
+// which holds "METHOD /pattern" keys
+func (t Timeouts) UnknownRoutes(known []string) []string {
+	var unknown []string
+	for route := range t.Routes {
+		if !slices.Contains(known, route) {
+			unknown = append(unknown, route)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
+
+// RouteTimeouts holds the current Timeouts so they can be swapped while
+// the gateway is serving, e.g. on config reload
+type RouteTimeouts struct {
+	current atomic.Pointer[Timeouts]
+}
+
+// NewRouteTimeouts starts with t
+func NewRouteTimeouts(t Timeouts) *RouteTimeouts {
+	rt := &RouteTimeouts{}
+	rt.Store(t)
+	return rt
+}
+
+// Store replaces the timeouts used for new requests
+func (rt *RouteTimeouts) Store(t Timeouts) {
+	rt.current.Store(&t)
+}
+
+// For returns the current timeout for a route
+func (rt *RouteTimeouts) For(method, pattern string) time.Duration {
+	return rt.current.Load().For(method, pattern)
+}
+
+// Middleware bounds each request by the route's timeout at the time the
+// request arrives
+func (rt *RouteTimeouts) Middleware(method, pattern string) func(http.Handler) http.Handler {
+	return TimeoutFunc(func() time.Duration { return rt.For(method, pattern) })
+}
+
+// Timeout bounds the request context by d. The deadline flows to the gRPC
+// calls made with r.Context(), so the backends stop work when it passes.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return TimeoutFunc(func() time.Duration { return d })
+}
+
+// TimeoutFunc is Timeout with the duration looked up per request
+func TimeoutFunc(d func() time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := d()
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"net/http"
+
+	"api-gateway/handlers"
+	"api-gateway/openapi"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// routeKeys returns the "METHOD /pattern" key of every REST API route
+func routeKeys() []string {
+	var keys []string
+	registerRoutes(chi.NewRouter(), &handlers.Handlers{}, func(method, pattern string, _ http.HandlerFunc) {
+		keys = append(keys, method+" "+pattern)
+	})
+	return keys
+}
+
+// registerRoutes registers every route the gateway serves. The REST API
+// goes through route, which adds the per-route middleware; each route must
+// also be described in openapi.Spec.
+func registerRoutes(r chi.Router, h *handlers.Handlers, route func(method, pattern string, handler http.HandlerFunc)) {
+	// Liveness and readiness probes, outside the rate limits so frequent
+	// polling is never throttled
+	r.Get("/healthz", h.Healthz)
+	r.Get("/readyz", h.Readyz)
+
+	// API description and reference docs
+	r.Get("/openapi.json", openapi.Handler)
+	r.Get("/docs", openapi.Docs)
+	r.Get("/docs/redoc.standalone.js", openapi.Redoc)
+
+	// User routes - HTTP to gRPC translation
+	route(http.MethodPost, "/api/users", h.CreateUser)
+	route(http.MethodGet, "/api/users/{id}", h.GetUser)
+	route(http.MethodGet, "/api/users", h.GetUsers)
+
+	// Menu routes - HTTP to gRPC translation
+	route(http.MethodPost, "/api/menu", h.CreateMenuItem)
+	route(http.MethodGet, "/api/menu/{id}", h.GetMenuItem)
+	route(http.MethodGet, "/api/menu", h.GetMenu)
+
+	// Order routes - HTTP to gRPC translation
+	route(http.MethodPost, "/api/orders", h.CreateOrder)
+	route(http.MethodGet, "/api/orders/{id}", h.GetOrder)
+	route(http.MethodPatch, "/api/orders/{id}", h.AmendOrder)
+	route(http.MethodGet, "/api/orders/{id}/receipt", h.GetOrderReceipt)
+	route(http.MethodGet, "/api/orders", h.GetOrders)
+}
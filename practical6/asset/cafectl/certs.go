@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/douglasswm/student-cafe-common/mtls"
+)
+
+// defaultIdentities are the services that get a certificate by default;
+// each name is also the identity servers check in TLS_ALLOWED_CLIENTS
+const defaultIdentities = "user-service,menu-service,order-service,api-gateway,cafectl"
+
+// certs writes a local CA and a certificate per service so mutual TLS can
+// be tried without any external PKI. Each identity gets a directory of its
+// own holding just ca.pem, cert.pem and key.pem, so a service can mount it
+// without seeing other keys; the CA key is kept in a separate directory
+// that is never mounted. Running it again reissues the service
+// certificates from the same CA, which the services pick up without a
+// restart
+func certs(args []string, stdout io.Writer) error {
+	fs := flags("certs")
+	dir := fs.String("dir", "certs", "directory to write a subdirectory of PEM files per identity to")
+	caDir := fs.String("ca-dir", "ca", "directory to keep the CA certificate and key in, outside -dir")
+	services := fs.String("services", defaultIdentities, "comma-separated names to issue certificates for")
+	hosts := fs.String("hosts", "", "extra comma-separated DNS names or IPs for every certificate")
+	days := fs.Int("days", 365, "days the service certificates are valid")
+	newCA := fs.Bool("new-ca", false, "replace the CA, still trusting the old one until -prune")
+	prune := fs.Bool("prune", false, "trust only the current CA, dropping any replaced by -new-ca")
+	if err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if *days <= 0 {
+		return usageError("certs: -days must be positive")
+	}
+	if inside, err := within(*caDir, *dir); err != nil {
+		return err
+	} else if inside {
+		return usageError("certs: -ca-dir must be outside -dir, which is mounted into the services")
+	}
+	if err := os.MkdirAll(*caDir, 0o700); err != nil {
+		return err
+	}
+
+	caFile, caKeyFile := filepath.Join(*caDir, "ca.pem"), filepath.Join(*caDir, "ca-key.pem")
+	ca, bundle, err := loadOrCreateCA(caFile, caKeyFile, *newCA)
+	if err != nil {
+		return err
+	}
+	if *prune {
+		bundle = ca.CertPEM()
+	}
+	if err := mtls.WriteFile(caFile, bundle, 0o644); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "IDENTITY\tCERT\tKEY\tEXPIRES")
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", "(CA)", caFile, caKeyFile, ca.Cert.NotAfter.Format(time.DateOnly))
+	validFor := time.Duration(*days) * 24 * time.Hour
+	for _, name := range splitList(*services) {
+		certPEM, keyPEM, err := ca.Issue(name, append([]string{name, "localhost", "127.0.0.1"}, splitList(*hosts)...), validFor)
+		if err != nil {
+			return fmt.Errorf("failed to issue certificate for %s: %w", name, err)
+		}
+		identityDir := filepath.Join(*dir, name)
+		if err := os.MkdirAll(identityDir, 0o755); err != nil {
+			return err
+		}
+		certFile, keyFile := filepath.Join(identityDir, "cert.pem"), filepath.Join(identityDir, "key.pem")
+		if err := mtls.WriteFile(filepath.Join(identityDir, "ca.pem"), bundle, 0o644); err != nil {
+			return err
+		}
+		// A service reading between the two writes finds a mismatched pair
+		// and keeps its current certificate until the next check
+		if err := mtls.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+			return err
+		}
+		if err := mtls.WriteFile(certFile, certPEM, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name, certFile, keyFile, time.Now().Add(validFor).Format(time.DateOnly))
+	}
+	return tw.Flush()
+}
+
+// within reports whether path is dir or inside it
+func within(path, dir string) (bool, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false, err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false, err
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil {
+		return false, err
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)), nil
+}
+
+// loadOrCreateCA reuses the CA in caFile unless replace is set or there is
+// none yet. It returns the CA and the bundle of CAs to trust, which keeps
+// a replaced CA so certificates it issued stay valid during the rotation
+func loadOrCreateCA(caFile, keyFile string, replace bool) (*mtls.CA, []byte, error) {
+	existing, err := os.ReadFile(caFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		existing, replace = nil, true
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	if !replace {
+		ca, err := mtls.LoadCA(caFile, keyFile)
+		return ca, existing, err
+	}
+	ca, err := mtls.NewCA("Student Cafe Local CA", 5*365*24*time.Hour)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := ca.KeyPEM()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := mtls.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		return nil, nil, err
+	}
+	return ca, append(ca.CertPEM(), existing...), nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+)
+
+// command is one "<resource> <verb>" subcommand
+type command struct {
+	name string
+	args string
+	help string
+	run  func(ctx context.Context, a *app, args []string) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"users list", "", "List every user", usersList},
+		{"users get", "ID", "Show one user", usersGet},
+		{"users create", "-name NAME -email EMAIL [-owner]", "Create a user", usersCreate},
+		{"menu list", "", "List the menu", menuList},
+		{"menu get", "ID", "Show one menu item", menuGet},
+		{"menu create", "-name NAME -price PRICE [-description TEXT]", "Add a menu item", menuCreate},
+		{"menu stock", "[-in] ID", "Mark a menu item out of stock, or back in stock with -in", menuStock},
+		{"orders list", "", "List every order", ordersList},
+		{"orders get", "ID", "Show one order with its items", ordersGet},
+		{"orders create", "-user ID -item ID[:QTY] [-item ...]", "Place an order", ordersCreate},
+		{"orders watch", "[-interval 2s] [ID]", "Print orders as they are placed or change", ordersWatch},
+	}
+}
+
+// flags returns a flag set for the named command that reports errors
+// as usage errors instead of exiting
+func flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parse parses args and checks the number of positional arguments
+func parse(fs *flag.FlagSet, args []string, positional int) error {
+	if err := fs.Parse(args); err != nil {
+		return usageError(fmt.Sprintf("%s: %v", fs.Name(), err))
+	}
+	if fs.NArg() != positional {
+		return usageError(fmt.Sprintf("%s: expected %d argument(s), got %d", fs.Name(), positional, fs.NArg()))
+	}
+	return nil
+}
+
+// parseID parses a positive resource ID
+func parseID(s string) (uint32, error) {
+	id, err := strconv.ParseUint(s, 10, 32)
+	if err != nil || id == 0 {
+		return 0, usageError(fmt.Sprintf("invalid ID %q", s))
+	}
+	return uint32(id), nil
+}
+
+// idArg parses the single ID argument of a get command
+func idArg(name string, args []string) (uint32, error) {
+	fs := flags(name)
+	if err := parse(fs, args, 1); err != nil {
+		return 0, err
+	}
+	return parseID(fs.Arg(0))
+}
+
+func usersList(ctx context.Context, a *app, args []string) error {
+	if err := parse(flags("users list"), args, 0); err != nil {
+		return err
+	}
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	resp, err := a.users.GetUsers(ctx, &userv1.GetUsersRequest{})
+	if err != nil {
+		return err
+	}
+	return a.out.users(resp.Users)
+}
+
+func usersGet(ctx context.Context, a *app, args []string) error {
+	id, err := idArg("users get", args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	resp, err := a.users.GetUser(ctx, &userv1.GetUserRequest{Id: id})
+	if err != nil {
+		return err
+	}
+	return a.out.user(resp.User)
+}
+
+func usersCreate(ctx context.Context, a *app, args []string) error {
+	fs := flags("users create")
+	name := fs.String("name", "", "user name")
+	email := fs.String("email", "", "email address")
+	owner := fs.Bool("owner", false, "make the user a cafe owner")
+	if err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	resp, err := a.users.CreateUser(ctx, &userv1.CreateUserRequest{Name: *name, Email: *email, IsCafeOwner: *owner})
+	if err != nil {
+		return err
+	}
+	return a.out.user(resp.User)
+}
+
+func menuList(ctx context.Context, a *app, args []string) error {
+	if err := parse(flags("menu list"), args, 0); err != nil {
+		return err
+	}
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	resp, err := a.menu.GetMenu(ctx, &menuv1.GetMenuRequest{})
+	if err != nil {
+		return err
+	}
+	return a.out.menuItems(resp.MenuItems)
+}
+
+func menuGet(ctx context.Context, a *app, args []string) error {
+	id, err := idArg("menu get", args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	resp, err := a.menu.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: id})
+	if err != nil {
+		return err
+	}
+	return a.out.menuItem(resp.MenuItem)
+}
+
+func menuCreate(ctx context.Context, a *app, args []string) error {
+	fs := flags("menu create")
+	name := fs.String("name", "", "item name")
+	description := fs.String("description", "", "item description")
+	price := fs.Float64("price", 0, "price in dollars")
+	if err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	resp, err := a.menu.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: *name, Description: *description, Price: *price})
+	if err != nil {
+		return err
+	}
+	return a.out.menuItem(resp.MenuItem)
+}
+
+func menuStock(ctx context.Context, a *app, args []string) error {
+	fs := flags("menu stock")
+	in := fs.Bool("in", false, "mark the item back in stock")
+	if err := parse(fs, args, 1); err != nil {
+		return err
+	}
+	id, err := parseID(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	resp, err := a.menu.SetMenuItemStock(ctx, &menuv1.SetMenuItemStockRequest{Id: id, OutOfStock: !*in})
+	if err != nil {
+		return err
+	}
+	return a.out.menuItem(resp.MenuItem)
+}
+
+func ordersList(ctx context.Context, a *app, args []string) error {
+	if err := parse(flags("orders list"), args, 0); err != nil {
+		return err
+	}
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	resp, err := a.orders.GetOrders(ctx, &orderv1.GetOrdersRequest{})
+	if err != nil {
+		return err
+	}
+	return a.out.orders(resp.Orders)
+}
+
+func ordersGet(ctx context.Context, a *app, args []string) error {
+	id, err := idArg("orders get", args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	resp, err := a.orders.GetOrder(ctx, &orderv1.GetOrderRequest{Id: id})
+	if err != nil {
+		return err
+	}
+	return a.out.order(resp.Order)
+}
+
+// itemFlags collects repeated -item ID[:QTY] flags
+type itemFlags []*orderv1.OrderItemRequest
+
+func (f *itemFlags) String() string { return "" }
+
+func (f *itemFlags) Set(s string) error {
+	idPart, qtyPart, hasQty := strings.Cut(s, ":")
+	id, err := parseID(idPart)
+	if err != nil {
+		return err
+	}
+	qty := int64(1)
+	if hasQty {
+		if qty, err = strconv.ParseInt(qtyPart, 10, 32); err != nil || qty <= 0 {
+			return fmt.Errorf("invalid quantity %q", qtyPart)
+		}
+	}
+	*f = append(*f, &orderv1.OrderItemRequest{MenuItemId: id, Quantity: int32(qty)})
+	return nil
+}
+
+func ordersCreate(ctx context.Context, a *app, args []string) error {
+	fs := flags("orders create")
+	user := fs.Uint("user", 0, "ID of the user placing the order")
+	var items itemFlags
+	fs.Var(&items, "item", "menu item ID with an optional :quantity (repeatable)")
+	if err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	resp, err := a.orders.CreateOrder(ctx, &orderv1.CreateOrderRequest{UserId: uint32(*user), Items: items})
+	if err != nil {
+		return err
+	}
+	return a.out.order(resp.Order)
+}
@@ -0,0 +1,204 @@
+// Command cafectl manages the cafe by calling the user, menu and order
+// services directly over gRPC, e.g.
+//
+//	cafectl users create -name Ada -email ada@example.com
+//	cafectl menu list
+//	cafectl -o yaml orders get 42
+//	cafectl orders watch
+//	cafectl certs -dir ./certs -ca-dir ./ca
+//
+// Service addresses come from USER_SERVICE_GRPC_ADDR, MENU_SERVICE_GRPC_ADDR
+// and ORDER_SERVICE_GRPC_ADDR (or a CONFIG_FILE), as for the services, and
+// TLS_CERT_FILE, TLS_KEY_FILE and TLS_CA_FILE turn on mutual TLS.
+// Addresses such as discovery:///menu-service are looked up in the
+// registry named by DISCOVERY_TYPE and DISCOVERY_ADDR.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/douglasswm/student-cafe-common/config"
+	"github.com/douglasswm/student-cafe-common/discovery"
+	"github.com/douglasswm/student-cafe-common/grpcclient"
+	"github.com/douglasswm/student-cafe-common/mtls"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// app holds the clients and output settings shared by every command
+type app struct {
+	users  userv1.UserServiceClient
+	menu   menuv1.MenuServiceClient
+	orders orderv1.OrderServiceClient
+	out    printer
+	// stderr gets warnings that do not end a command
+	stderr io.Writer
+	// timeout bounds each call; watch applies it per poll
+	timeout time.Duration
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes one cafectl invocation and returns its exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("cafectl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("o", FormatTable, "output format: table, json or yaml")
+	timeout := fs.Duration("timeout", 10*time.Second, "deadline for each call")
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	out, err := newPrinter(stdout, *format)
+	if err != nil {
+		fmt.Fprintln(stderr, "cafectl:", err)
+		return 2
+	}
+
+	if fs.Arg(0) == "certs" {
+		// Writes files only, so it needs no configuration or connections
+		return exitCode(certs(fs.Args()[1:], stdout), fs, stderr)
+	}
+
+	cfg, err := config.Load(config.Options{
+		Service: "cafectl",
+		Defaults: config.Config{
+			UserServiceAddr:  "localhost:9091",
+			MenuServiceAddr:  "localhost:9092",
+			OrderServiceAddr: "localhost:9093",
+		},
+	})
+	if err != nil {
+		fmt.Fprintln(stderr, "cafectl:", err)
+		return 2
+	}
+
+	creds, err := mtls.ClientCredentials(cfg.TLS())
+	if err != nil {
+		fmt.Fprintln(stderr, "cafectl:", err)
+		return 1
+	}
+
+	registry, err := discovery.New(cfg.Discovery())
+	if err != nil {
+		fmt.Fprintln(stderr, "cafectl:", err)
+		return 1
+	}
+
+	var conns []*grpcclient.Conn
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	dial := func(addr string, policy grpcclient.Policy) grpc.ClientConnInterface {
+		conn, err := grpcclient.Dial(addr, policy, grpc.WithTransportCredentials(creds), discovery.DialOption(registry))
+		if err != nil {
+			// Only malformed targets fail here; report them on first use
+			return failedConn{fmt.Errorf("%s at %q: %w", policy.Name, addr, err)}
+		}
+		conns = append(conns, conn)
+		return conn
+	}
+	a := &app{
+		users:   userv1.NewUserServiceClient(dial(cfg.UserServiceAddr, grpcclient.UserServicePolicy())),
+		menu:    menuv1.NewMenuServiceClient(dial(cfg.MenuServiceAddr, grpcclient.MenuServicePolicy())),
+		orders:  orderv1.NewOrderServiceClient(dial(cfg.OrderServiceAddr, grpcclient.OrderServicePolicy())),
+		out:     out,
+		stderr:  stderr,
+		timeout: *timeout,
+	}
+
+	return exitCode(a.dispatch(ctx, fs.Args()), fs, stderr)
+}
+
+// exitCode reports err and returns 2 for usage errors, 1 for anything else
+func exitCode(err error, fs *flag.FlagSet, stderr io.Writer) int {
+	if err == nil {
+		return 0
+	}
+	var uerr usageError
+	if errors.As(err, &uerr) {
+		fmt.Fprintln(stderr, "cafectl:", err)
+		fs.Usage()
+		return 2
+	}
+	fmt.Fprintln(stderr, "cafectl:", describe(err))
+	return 1
+}
+
+// dispatch runs the command named by the first two arguments
+func (a *app) dispatch(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return usageError("expected a command")
+	}
+	name := args[0] + " " + args[1]
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(ctx, a, args[2:])
+		}
+	}
+	return usageError(fmt.Sprintf("unknown command %q", name))
+}
+
+// call returns a context for one RPC, bounded by the app's timeout
+func (a *app) call(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, a.timeout)
+}
+
+// usageError is a mistake in the command line rather than a failed call
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "Usage: cafectl [flags] <command> [args]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-16s %s\n", cmd.name, strings.TrimSpace(cmd.args))
+		fmt.Fprintf(w, "  %-16s   %s\n", "", cmd.help)
+	}
+	fmt.Fprintf(w, "  %-16s %s\n", "certs", "[-dir certs] [-ca-dir ca] [-services LIST] [-hosts LIST] [-days N] [-new-ca] [-prune]")
+	fmt.Fprintf(w, "  %-16s   %s\n", "", "Write a local CA and per-service certificates for mutual TLS")
+	fmt.Fprintln(w, "\nFlags:")
+	fs.PrintDefaults()
+	fmt.Fprintln(w, "\nService addresses are read from USER_SERVICE_GRPC_ADDR, MENU_SERVICE_GRPC_ADDR")
+	fmt.Fprintln(w, "and ORDER_SERVICE_GRPC_ADDR, or from the YAML file named by CONFIG_FILE. Set")
+	fmt.Fprintln(w, "TLS_CERT_FILE, TLS_KEY_FILE and TLS_CA_FILE to call services that require mTLS.")
+}
+
+// describe formats a gRPC error as "CODE: message"
+func describe(err error) string {
+	if st, ok := status.FromError(err); ok {
+		return fmt.Sprintf("%s: %s", st.Code(), st.Message())
+	}
+	return err.Error()
+}
+
+// failedConn fails every call with the error from dialing
+type failedConn struct{ err error }
+
+func (c failedConn) Invoke(context.Context, string, any, any, ...grpc.CallOption) error {
+	return c.err
+}
+
+func (c failedConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, c.err
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/douglasswm/student-cafe-common/mtls"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeUsers struct {
+	userv1.UnimplementedUserServiceServer
+	created *userv1.CreateUserRequest
+}
+
+func (s *fakeUsers) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.CreateUserResponse, error) {
+	s.created = req
+	return &userv1.CreateUserResponse{User: &userv1.User{Id: 7, Name: req.Name, Email: req.Email, IsCafeOwner: req.IsCafeOwner}}, nil
+}
+
+type fakeMenu struct {
+	menuv1.UnimplementedMenuServiceServer
+}
+
+func (s *fakeMenu) GetMenu(ctx context.Context, req *menuv1.GetMenuRequest) (*menuv1.GetMenuResponse, error) {
+	return &menuv1.GetMenuResponse{MenuItems: []*menuv1.MenuItem{
+		{Id: 1, Name: "Coffee", Price: 2.5},
+		{Id: 2, Name: "Muffin", Price: 3, OutOfStock: true},
+	}}, nil
+}
+
+func (s *fakeMenu) SetMenuItemStock(ctx context.Context, req *menuv1.SetMenuItemStockRequest) (*menuv1.SetMenuItemStockResponse, error) {
+	return &menuv1.SetMenuItemStockResponse{MenuItem: &menuv1.MenuItem{Id: req.Id, Name: "Muffin", Price: 3, OutOfStock: req.OutOfStock}}, nil
+}
+
+type fakeOrders struct {
+	orderv1.UnimplementedOrderServiceServer
+	mu     sync.Mutex
+	orders []*orderv1.Order
+	polls  int
+	// unavailable is how many GetOrders calls fail before any succeed
+	unavailable int
+}
+
+func (s *fakeOrders) GetOrder(ctx context.Context, req *orderv1.GetOrderRequest) (*orderv1.GetOrderResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range s.orders {
+		if o.Id == req.Id {
+			return &orderv1.GetOrderResponse{Order: o}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "order not found")
+}
+
+func (s *fakeOrders) GetOrders(ctx context.Context, req *orderv1.GetOrdersRequest) (*orderv1.GetOrdersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.unavailable > 0 {
+		s.unavailable--
+		return nil, status.Errorf(codes.Unavailable, "order service restarting")
+	}
+	s.polls++
+	// The second poll sees the order progress and a new one arrive
+	if s.polls == 2 {
+		s.orders[0].Status = "completed"
+		s.orders = append(s.orders, &orderv1.Order{Id: 43, UserId: 2, Status: "pending"})
+	}
+	return &orderv1.GetOrdersResponse{Orders: s.orders}, nil
+}
+
+// newTestApp serves the fakes over bufconn and returns an app that talks to them
+func newTestApp(t *testing.T, format string) (*app, *bytes.Buffer, *fakeUsers, *fakeOrders) {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	users := &fakeUsers{}
+	orders := &fakeOrders{orders: []*orderv1.Order{{
+		Id: 42, UserId: 1, Status: "pending", Revision: 1,
+		OrderItems: []*orderv1.OrderItem{{MenuItemId: 1, Name: "Coffee", Quantity: 2, Price: 2.5, LineTotal: 5}},
+	}}}
+	userv1.RegisterUserServiceServer(s, users)
+	menuv1.RegisterMenuServiceServer(s, &fakeMenu{})
+	orderv1.RegisterOrderServiceServer(s, orders)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	var out bytes.Buffer
+	p, err := newPrinter(&out, format)
+	require.NoError(t, err)
+	return &app{
+		users:   userv1.NewUserServiceClient(conn),
+		menu:    menuv1.NewMenuServiceClient(conn),
+		orders:  orderv1.NewOrderServiceClient(conn),
+		out:     p,
+		stderr:  io.Discard,
+		timeout: 5 * time.Second,
+	}, &out, users, orders
+}
+
+func TestUsersCreate(t *testing.T) {
+	a, out, users, _ := newTestApp(t, FormatJSON)
+	require.NoError(t, a.dispatch(context.Background(), []string{"users", "create", "-name", "Ada", "-email", "ada@example.com", "-owner"}))
+
+	assert.Equal(t, "Ada", users.created.Name)
+	assert.True(t, users.created.IsCafeOwner)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.EqualValues(t, 7, got["id"])
+	assert.Equal(t, true, got["is_cafe_owner"])
+}
+
+func TestMenuListFormats(t *testing.T) {
+	a, out, _, _ := newTestApp(t, FormatTable)
+	require.NoError(t, a.dispatch(context.Background(), []string{"menu", "list"}))
+	assert.Equal(t, "ID  NAME    PRICE  AVAILABLE\n1   Coffee  2.50   true\n2   Muffin  3.00   false\n", out.String())
+
+	a, out, _, _ = newTestApp(t, FormatJSON)
+	require.NoError(t, a.dispatch(context.Background(), []string{"menu", "list"}))
+	var items []map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &items))
+	require.Len(t, items, 2)
+	assert.Equal(t, "Muffin", items[1]["name"])
+
+	a, out, _, _ = newTestApp(t, FormatYAML)
+	require.NoError(t, a.dispatch(context.Background(), []string{"menu", "list"}))
+	assert.Contains(t, out.String(), "- id: 1\n  name: Coffee\n")
+	assert.Contains(t, out.String(), "out_of_stock: true")
+}
+
+func TestMenuStock(t *testing.T) {
+	a, out, _, _ := newTestApp(t, FormatJSON)
+	require.NoError(t, a.dispatch(context.Background(), []string{"menu", "stock", "2"}))
+	assert.Contains(t, out.String(), `"out_of_stock": true`)
+
+	a, out, _, _ = newTestApp(t, FormatJSON)
+	require.NoError(t, a.dispatch(context.Background(), []string{"menu", "stock", "-in", "2"}))
+	assert.Contains(t, out.String(), `"out_of_stock": false`)
+}
+
+func TestOrdersGet(t *testing.T) {
+	a, out, _, _ := newTestApp(t, FormatTable)
+	require.NoError(t, a.dispatch(context.Background(), []string{"orders", "get", "42"}))
+	assert.Contains(t, out.String(), "42  1     pending  1      5.00   1")
+	assert.Contains(t, out.String(), "Coffee")
+
+	err := a.dispatch(context.Background(), []string{"orders", "get", "7"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "NotFound: order not found", describe(err))
+}
+
+func TestUsageErrors(t *testing.T) {
+	a, _, _, _ := newTestApp(t, FormatTable)
+	for _, args := range [][]string{
+		{"orders"},
+		{"orders", "delete"},
+		{"orders", "get"},
+		{"orders", "get", "abc"},
+		{"orders", "create", "-item", "1:0"},
+	} {
+		var uerr usageError
+		assert.ErrorAs(t, a.dispatch(context.Background(), args), &uerr, strings.Join(args, " "))
+	}
+}
+
+func TestOrdersWatch(t *testing.T) {
+	a, out, _, orders := newTestApp(t, FormatJSON)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- a.dispatch(ctx, []string{"orders", "watch", "-interval", "10ms"}) }()
+
+	require.Eventually(t, func() bool {
+		orders.mu.Lock()
+		defer orders.mu.Unlock()
+		return orders.polls >= 3
+	}, 5*time.Second, 5*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	var events []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var ev struct {
+			Type  string `json:"type"`
+			Order struct {
+				ID     int    `json:"id"`
+				Status string `json:"status"`
+			} `json:"order"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &ev))
+		events = append(events, ev.Type+" "+ev.Order.Status)
+	}
+	// Unchanged orders are not repeated on later polls
+	assert.Equal(t, []string{"ADDED pending", "MODIFIED completed", "ADDED pending"}, events)
+}
+
+func TestOrdersWatchRetriesFailedPolls(t *testing.T) {
+	a, out, _, orders := newTestApp(t, FormatJSON)
+	var stderr bytes.Buffer
+	a.stderr = &stderr
+	orders.unavailable = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- a.dispatch(ctx, []string{"orders", "watch", "-interval", "10ms"}) }()
+
+	require.Eventually(t, func() bool {
+		orders.mu.Lock()
+		defer orders.mu.Unlock()
+		return orders.polls >= 1
+	}, 5*time.Second, 5*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.Equal(t, 2, strings.Count(stderr.String(), "cafectl: Unavailable: order service restarting"))
+	assert.Contains(t, out.String(), `"type":"ADDED"`)
+}
+
+func TestOrdersWatchStopsOnMissingOrder(t *testing.T) {
+	a, _, _, _ := newTestApp(t, FormatJSON)
+	err := a.dispatch(context.Background(), []string{"orders", "watch", "-interval", "10ms", "99"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRunRejectsUnknownFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, run(context.Background(), []string{"-o", "xml", "menu", "list"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), `unknown output format "xml"`)
+}
+
+func TestCerts(t *testing.T) {
+	root := t.TempDir()
+	dir, caDir := filepath.Join(root, "certs"), filepath.Join(root, "ca")
+	runCerts := func(args ...string) int {
+		var stdout, stderr bytes.Buffer
+		return run(context.Background(), append([]string{"certs", "-dir", dir, "-ca-dir", caDir}, args...), &stdout, &stderr)
+	}
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 0, run(context.Background(), []string{"certs", "-dir", dir, "-ca-dir", caDir, "-services", "menu-service,api-gateway"}, &stdout, &stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "api-gateway")
+
+	serviceDir := filepath.Join(dir, "menu-service")
+	cert, err := tls.LoadX509KeyPair(filepath.Join(serviceDir, "cert.pem"), filepath.Join(serviceDir, "key.pem"))
+	require.NoError(t, err)
+	assert.Equal(t, "menu-service", mtls.Identity(cert.Leaf))
+	assert.Contains(t, cert.Leaf.DNSNames, "localhost")
+	info, err := os.Stat(filepath.Join(serviceDir, "key.pem"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// A service's directory holds only its own key and the trust bundle
+	entries, err := os.ReadDir(serviceDir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"ca.pem", "cert.pem", "key.pem"}, names)
+	_, err = os.Stat(filepath.Join(caDir, "ca-key.pem"))
+	assert.NoError(t, err)
+
+	caCount := func(file string) int {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		return strings.Count(string(data), "BEGIN CERTIFICATE")
+	}
+	bundle := filepath.Join(serviceDir, "ca.pem")
+	// Reissuing keeps the CA; -new-ca trusts old and new until -prune
+	require.Equal(t, 0, runCerts())
+	assert.Equal(t, 1, caCount(bundle))
+	require.Equal(t, 0, runCerts("-new-ca"))
+	assert.Equal(t, 2, caCount(bundle))
+	assert.Equal(t, 2, caCount(filepath.Join(caDir, "ca.pem")))
+	require.Equal(t, 0, runCerts("-prune"))
+	assert.Equal(t, 1, caCount(bundle))
+
+	assert.Equal(t, 2, runCerts("-days", "0"))
+	assert.Equal(t, 2, runCerts("-ca-dir", filepath.Join(dir, "ca")))
+}
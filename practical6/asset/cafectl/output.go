@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by -o
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+// marshaler matches the gateway's JSON so both tools print the same fields
+var marshaler = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// printer writes results in the format chosen with -o
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (printer, error) {
+	switch format {
+	case FormatTable, FormatJSON, FormatYAML:
+		return printer{w: w, format: format}, nil
+	}
+	return printer{}, fmt.Errorf("unknown output format %q (want table, json or yaml)", format)
+}
+
+// encode writes v, which is a message or a list of messages, as JSON or YAML
+func (p printer) encode(v any) error {
+	raw, err := toJSON(v)
+	if err != nil {
+		return err
+	}
+	if p.format == FormatYAML {
+		out, err := jsonToYAML(raw)
+		if err != nil {
+			return err
+		}
+		_, err = p.w.Write(out)
+		return err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err = buf.WriteTo(p.w)
+	return err
+}
+
+// toJSON encodes a message, or a slice of messages as a JSON array
+func toJSON(v any) ([]byte, error) {
+	if m, ok := v.(proto.Message); ok {
+		return marshaler.Marshal(m)
+	}
+	msgs := v.([]proto.Message)
+	items := make([]json.RawMessage, 0, len(msgs))
+	for _, m := range msgs {
+		raw, err := marshaler.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, raw)
+	}
+	return json.Marshal(items)
+}
+
+// jsonToYAML re-encodes JSON as block-style YAML
+func jsonToYAML(raw []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(raw, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+// blockStyle drops the flow style yaml.v3 keeps from JSON input
+func blockStyle(n *yaml.Node) {
+	if n.Kind == yaml.MappingNode || n.Kind == yaml.SequenceNode {
+		n.Style = 0
+	}
+	if n.Kind == yaml.ScalarNode && n.Style == yaml.DoubleQuotedStyle {
+		n.Style = 0
+	}
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// table writes rows through a tabwriter
+func (p printer) table(header string, rows func(w io.Writer)) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, header)
+	rows(tw)
+	return tw.Flush()
+}
+
+func messages[M proto.Message](ms []M) []proto.Message {
+	out := make([]proto.Message, len(ms))
+	for i, m := range ms {
+		out[i] = m
+	}
+	return out
+}
+
+func (p printer) users(users []*userv1.User) error {
+	if p.format != FormatTable {
+		return p.encode(messages(users))
+	}
+	return p.table("ID\tNAME\tEMAIL\tOWNER\tCREATED", func(w io.Writer) {
+		for _, u := range users {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%t\t%s\n", u.Id, u.Name, u.Email, u.IsCafeOwner, u.CreatedAt)
+		}
+	})
+}
+
+func (p printer) user(u *userv1.User) error {
+	if p.format != FormatTable {
+		return p.encode(u)
+	}
+	return p.users([]*userv1.User{u})
+}
+
+func (p printer) menuItems(items []*menuv1.MenuItem) error {
+	if p.format != FormatTable {
+		return p.encode(messages(items))
+	}
+	return p.table("ID\tNAME\tPRICE\tAVAILABLE", func(w io.Writer) {
+		for _, m := range items {
+			fmt.Fprintf(w, "%d\t%s\t%.2f\t%t\n", m.Id, m.Name, m.Price, !m.OutOfStock)
+		}
+	})
+}
+
+func (p printer) menuItem(m *menuv1.MenuItem) error {
+	if p.format != FormatTable {
+		return p.encode(m)
+	}
+	return p.menuItems([]*menuv1.MenuItem{m})
+}
+
+func (p printer) orders(orders []*orderv1.Order) error {
+	if p.format != FormatTable {
+		return p.encode(messages(orders))
+	}
+	return p.table("ID\tUSER\tSTATUS\tITEMS\tTOTAL\tREVISION\tCREATED", func(w io.Writer) {
+		for _, o := range orders {
+			orderRow(w, o)
+		}
+	})
+}
+
+func orderRow(w io.Writer, o *orderv1.Order) {
+	fmt.Fprintf(w, "%d\t%d\t%s\t%d\t%.2f\t%d\t%s\n", o.Id, o.UserId, o.Status, len(o.OrderItems), orderTotal(o), o.Revision, o.CreatedAt)
+}
+
+// order prints the order summary followed by its items
+func (p printer) order(o *orderv1.Order) error {
+	if p.format != FormatTable {
+		return p.encode(o)
+	}
+	if err := p.orders([]*orderv1.Order{o}); err != nil {
+		return err
+	}
+	if len(o.OrderItems) == 0 {
+		return nil
+	}
+	fmt.Fprintln(p.w)
+	return p.table("MENU ITEM\tNAME\tQUANTITY\tPRICE\tLINE TOTAL", func(w io.Writer) {
+		for _, item := range o.OrderItems {
+			fmt.Fprintf(w, "%d\t%s\t%d\t%.2f\t%.2f\n", item.MenuItemId, item.Name, item.Quantity, item.Price, lineTotal(item))
+		}
+	})
+}
+
+// lineTotal falls back to price × quantity for items stored before
+// line totals were recorded
+func lineTotal(item *orderv1.OrderItem) float64 {
+	if item.LineTotal != 0 {
+		return item.LineTotal
+	}
+	return item.Price * float64(item.Quantity)
+}
+
+func orderTotal(o *orderv1.Order) float64 {
+	var total float64
+	for _, item := range o.OrderItems {
+		total += lineTotal(item)
+	}
+	return total
+}
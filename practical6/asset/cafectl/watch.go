@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Watch event types, named after the Kubernetes watch events
+const (
+	EventAdded    = "ADDED"
+	EventModified = "MODIFIED"
+)
+
+// ordersWatch polls the order service and prints each order when it
+// first appears and whenever its status or revision changes. The order
+// service has no streaming API, so polling is the only option. A failed
+// poll is reported and retried on the next tick unless retrying cannot help
+func ordersWatch(ctx context.Context, a *app, args []string) error {
+	fs := flags("orders watch")
+	interval := fs.Duration("interval", 2*time.Second, "time between polls")
+	if err := fs.Parse(args); err != nil {
+		return usageError(fmt.Sprintf("orders watch: %v", err))
+	}
+	if fs.NArg() > 1 {
+		return usageError("orders watch: expected at most one order ID")
+	}
+	if *interval <= 0 {
+		return usageError("orders watch: -interval must be positive")
+	}
+	var id uint32
+	if fs.NArg() == 1 {
+		var err error
+		if id, err = parseID(fs.Arg(0)); err != nil {
+			return err
+		}
+	}
+
+	seen := make(map[uint32]string)
+	header := true
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		orders, err := a.pollOrders(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if !retryable(err) {
+				return err
+			}
+			fmt.Fprintln(a.stderr, "cafectl:", describe(err))
+		}
+		for _, o := range orders {
+			key := fmt.Sprintf("%s/%d", o.Status, o.Revision)
+			prev, ok := seen[o.Id]
+			if ok && prev == key {
+				continue
+			}
+			seen[o.Id] = key
+			event := EventModified
+			if !ok {
+				event = EventAdded
+			}
+			if err := a.out.event(event, o, header); err != nil {
+				return err
+			}
+			header = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// retryable reports whether a failed poll may succeed on the next tick,
+// e.g. after a deadline or while the order service restarts, rather than
+// failing the same way every time
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.NotFound, codes.InvalidArgument, codes.PermissionDenied,
+		codes.Unauthenticated, codes.Unimplemented:
+		return false
+	}
+	return true
+}
+
+// pollOrders fetches one order, or every order when id is 0
+func (a *app) pollOrders(ctx context.Context, id uint32) ([]*orderv1.Order, error) {
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	if id != 0 {
+		resp, err := a.orders.GetOrder(ctx, &orderv1.GetOrderRequest{Id: id})
+		if err != nil {
+			return nil, err
+		}
+		return []*orderv1.Order{resp.Order}, nil
+	}
+	resp, err := a.orders.GetOrders(ctx, &orderv1.GetOrdersRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Orders, nil
+}
+
+// event prints one watch event: a table row, a line of JSON or a YAML
+// document. header is set for the first event so tables get a heading
+func (p printer) event(event string, o *orderv1.Order, header bool) error {
+	switch p.format {
+	case FormatJSON:
+		raw, err := marshaler.Marshal(o)
+		if err != nil {
+			return err
+		}
+		line, err := json.Marshal(struct {
+			Type  string          `json:"type"`
+			Order json.RawMessage `json:"order"`
+		}{event, raw})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.w, "%s\n", line)
+		return err
+	case FormatYAML:
+		fmt.Fprintln(p.w, "---")
+		raw, err := marshaler.Marshal(o)
+		if err != nil {
+			return err
+		}
+		out, err := jsonToYAML([]byte(fmt.Sprintf(`{"type":%q,"order":%s}`, event, raw)))
+		if err != nil {
+			return err
+		}
+		_, err = p.w.Write(out)
+		return err
+	}
+	// Rows arrive over time, so use fixed widths rather than a tabwriter
+	const row = "%-9s %-6v %-6v %-10s %-6v %-8v %-9v %s\n"
+	if header {
+		fmt.Fprintf(p.w, row, "EVENT", "ID", "USER", "STATUS", "ITEMS", "TOTAL", "REVISION", "CREATED")
+	}
+	_, err := fmt.Fprintf(p.w, row, event, o.Id, o.UserId, o.Status, len(o.OrderItems), fmt.Sprintf("%.2f", orderTotal(o)), o.Revision, o.CreatedAt)
+	return err
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
@@ -25,7 +26,7 @@ func NewMenuServer() *MenuServer {
 // GetMenuItem retrieves a menu item by ID
 func (s *MenuServer) GetMenuItem(ctx context.Context, req *menuv1.GetMenuItemRequest) (*menuv1.GetMenuItemResponse, error) {
 	var menuItem models.MenuItem
-	if err := database.DB.First(&menuItem, req.Id).Error; err != nil {
+	if err := database.DB.WithContext(ctx).Scopes(preloadMenuItem).First(&menuItem, req.Id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, status.Errorf(codes.NotFound, "menu item not found")
 		}
@@ -40,7 +41,7 @@ func (s *MenuServer) GetMenuItem(ctx context.Context, req *menuv1.GetMenuItemReq
 // GetMenu retrieves all menu items
 func (s *MenuServer) GetMenu(ctx context.Context, req *menuv1.GetMenuRequest) (*menuv1.GetMenuResponse, error) {
 	var menuItems []models.MenuItem
-	if err := database.DB.Find(&menuItems).Error; err != nil {
+	if err := database.DB.WithContext(ctx).Scopes(preloadMenuItem).Find(&menuItems).Error; err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get menu: %v", err)
 	}
 
@@ -62,7 +63,26 @@ func (s *MenuServer) CreateMenuItem(ctx context.Context, req *menuv1.CreateMenuI
 		Price:       req.Price,
 	}
 
-	if err := database.DB.Create(&menuItem).Error; err != nil {
+	for _, group := range req.ModifierGroups {
+		modelGroup, err := modifierGroupFromProto(group)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid modifier group %q: %v", group.Name, err)
+		}
+		menuItem.ModifierGroups = append(menuItem.ModifierGroups, modelGroup)
+	}
+
+	for _, slot := range req.BundleSlots {
+		modelSlot, err := bundleSlotFromProto(slot)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid bundle slot %q: %v", slot.Name, err)
+		}
+		menuItem.BundleSlots = append(menuItem.BundleSlots, modelSlot)
+	}
+	if err := validateBundleChoices(ctx, menuItem.BundleSlots); err != nil {
+		return nil, err
+	}
+
+	if err := database.DB.WithContext(ctx).Create(&menuItem).Error; err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create menu item: %v", err)
 	}
 
@@ -71,14 +91,226 @@ func (s *MenuServer) CreateMenuItem(ctx context.Context, req *menuv1.CreateMenuI
 	}, nil
 }
 
+// menuTables are the models a menu item is read from
+var menuTables = []interface{}{
+	&models.MenuItem{}, &models.ModifierGroup{}, &models.ModifierOption{},
+	&models.BundleSlot{}, &models.BundleChoice{},
+}
+
+// GetMenuVersion returns a version derived from the number of rows and the
+// latest update across the menu item tables and their modifier and bundle
+// tables, so it changes whenever an item or any of its parts is added,
+// updated or deleted
+func (s *MenuServer) GetMenuVersion(ctx context.Context, req *menuv1.GetMenuVersionRequest) (*menuv1.GetMenuVersionResponse, error) {
+	var total, latest int64
+	for _, table := range menuTables {
+		var count int64
+		if err := database.DB.WithContext(ctx).Model(table).Count(&count).Error; err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get menu version: %v", err)
+		}
+		total += count
+
+		var updated []time.Time
+		if err := database.DB.WithContext(ctx).Model(table).
+			Order("updated_at DESC").Limit(1).Pluck("updated_at", &updated).Error; err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get menu version: %v", err)
+		}
+		if len(updated) > 0 && updated[0].UnixNano() > latest {
+			latest = updated[0].UnixNano()
+		}
+	}
+
+	return &menuv1.GetMenuVersionResponse{
+		Version: fmt.Sprintf("%d-%x", total, latest),
+	}, nil
+}
+
+// SetMenuItemStock marks a menu item as in or out of stock. Orders for an
+// out-of-stock item are rejected by order-service.
+func (s *MenuServer) SetMenuItemStock(ctx context.Context, req *menuv1.SetMenuItemStockRequest) (*menuv1.SetMenuItemStockResponse, error) {
+	db := database.DB.WithContext(ctx)
+
+	// Update by ID rather than through a loaded item, which would also
+	// save its preloaded modifier groups and bundle slots
+	result := db.Model(&models.MenuItem{}).Where("id = ?", req.Id).Update("out_of_stock", req.OutOfStock)
+	if result.Error != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update menu item: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Errorf(codes.NotFound, "menu item not found")
+	}
+
+	var menuItem models.MenuItem
+	if err := db.Scopes(preloadMenuItem).First(&menuItem, req.Id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Errorf(codes.NotFound, "menu item not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get menu item: %v", err)
+	}
+
+	return &menuv1.SetMenuItemStockResponse{
+		MenuItem: modelToProto(&menuItem),
+	}, nil
+}
+
+// preloadMenuItem loads a menu item's modifier groups and bundle slots
+func preloadMenuItem(db *gorm.DB) *gorm.DB {
+	return db.Preload("BundleSlots.Choices").Preload("ModifierGroups.Options")
+}
+
+// bundleSlotFromProto validates a requested bundle slot and converts it to a GORM model
+func bundleSlotFromProto(slot *menuv1.BundleSlot) (models.BundleSlot, error) {
+	if slot.Name == "" {
+		return models.BundleSlot{}, fmt.Errorf("name is required")
+	}
+	if len(slot.Choices) == 0 {
+		return models.BundleSlot{}, fmt.Errorf("at least one choice is required")
+	}
+
+	modelSlot := models.BundleSlot{Name: slot.Name}
+	seen := make(map[uint32]bool)
+	for _, choice := range slot.Choices {
+		if seen[choice.MenuItemId] {
+			return models.BundleSlot{}, fmt.Errorf("menu item %d is listed more than once", choice.MenuItemId)
+		}
+		seen[choice.MenuItemId] = true
+		if choice.Upcharge < 0 {
+			return models.BundleSlot{}, fmt.Errorf("upcharge for menu item %d cannot be negative", choice.MenuItemId)
+		}
+		modelSlot.Choices = append(modelSlot.Choices, models.BundleChoice{
+			MenuItemID: uint(choice.MenuItemId),
+			Upcharge:   choice.Upcharge,
+		})
+	}
+
+	return modelSlot, nil
+}
+
+// validateBundleChoices checks that every bundle choice refers to an
+// existing menu item that is not itself a bundle
+func validateBundleChoices(ctx context.Context, slots []models.BundleSlot) error {
+	ids := make(map[uint]bool)
+	for _, slot := range slots {
+		for _, choice := range slot.Choices {
+			ids[choice.MenuItemID] = true
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	idList := make([]uint, 0, len(ids))
+	for id := range ids {
+		idList = append(idList, id)
+	}
+
+	var found []models.MenuItem
+	if err := database.DB.WithContext(ctx).Preload("BundleSlots").Find(&found, idList).Error; err != nil {
+		return status.Errorf(codes.Internal, "failed to check bundle choices: %v", err)
+	}
+	for _, item := range found {
+		if len(item.BundleSlots) > 0 {
+			return status.Errorf(codes.InvalidArgument, "menu item %d is a bundle and cannot be a bundle choice", item.ID)
+		}
+		delete(ids, item.ID)
+	}
+	for id := range ids {
+		return status.Errorf(codes.InvalidArgument, "bundle choice menu item %d not found", id)
+	}
+
+	return nil
+}
+
+// modifierGroupFromProto validates a requested modifier group and converts it to a GORM model
+func modifierGroupFromProto(group *menuv1.ModifierGroup) (models.ModifierGroup, error) {
+	if group.Name == "" {
+		return models.ModifierGroup{}, fmt.Errorf("name is required")
+	}
+	if len(group.Options) == 0 {
+		return models.ModifierGroup{}, fmt.Errorf("at least one option is required")
+	}
+
+	minSelections := int(group.MinSelections)
+	maxSelections := int(group.MaxSelections)
+	if group.Required && minSelections < 1 {
+		minSelections = 1
+	}
+	if minSelections < 0 || maxSelections < 0 {
+		return models.ModifierGroup{}, fmt.Errorf("selection limits cannot be negative")
+	}
+	if maxSelections > 0 && maxSelections < minSelections {
+		return models.ModifierGroup{}, fmt.Errorf("max_selections (%d) is less than min_selections (%d)", maxSelections, minSelections)
+	}
+	if minSelections > len(group.Options) {
+		return models.ModifierGroup{}, fmt.Errorf("min_selections (%d) exceeds the number of options (%d)", minSelections, len(group.Options))
+	}
+
+	modelGroup := models.ModifierGroup{
+		Name:          group.Name,
+		Required:      minSelections > 0,
+		MinSelections: minSelections,
+		MaxSelections: maxSelections,
+	}
+	for _, option := range group.Options {
+		if option.Name == "" {
+			return models.ModifierGroup{}, fmt.Errorf("option name is required")
+		}
+		modelGroup.Options = append(modelGroup.Options, models.ModifierOption{
+			Name:       option.Name,
+			PriceDelta: option.PriceDelta,
+		})
+	}
+
+	return modelGroup, nil
+}
+
 // modelToProto converts a GORM MenuItem model to proto MenuItem message
 func modelToProto(item *models.MenuItem) *menuv1.MenuItem {
+	groups := make([]*menuv1.ModifierGroup, len(item.ModifierGroups))
+	for i, group := range item.ModifierGroups {
+		options := make([]*menuv1.ModifierOption, len(group.Options))
+		for j, option := range group.Options {
+			options[j] = &menuv1.ModifierOption{
+				Id:         uint32(option.ID),
+				Name:       option.Name,
+				PriceDelta: option.PriceDelta,
+			}
+		}
+		groups[i] = &menuv1.ModifierGroup{
+			Id:            uint32(group.ID),
+			Name:          group.Name,
+			Required:      group.Required,
+			MinSelections: int32(group.MinSelections),
+			MaxSelections: int32(group.MaxSelections),
+			Options:       options,
+		}
+	}
+
+	slots := make([]*menuv1.BundleSlot, len(item.BundleSlots))
+	for i, slot := range item.BundleSlots {
+		choices := make([]*menuv1.BundleChoice, len(slot.Choices))
+		for j, choice := range slot.Choices {
+			choices[j] = &menuv1.BundleChoice{
+				MenuItemId: uint32(choice.MenuItemID),
+				Upcharge:   choice.Upcharge,
+			}
+		}
+		slots[i] = &menuv1.BundleSlot{
+			Id:      uint32(slot.ID),
+			Name:    slot.Name,
+			Choices: choices,
+		}
+	}
+
 	return &menuv1.MenuItem{
-		Id:          uint32(item.ID),
-		Name:        item.Name,
-		Description: item.Description,
-		Price:       item.Price,
-		CreatedAt:   item.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   item.UpdatedAt.Format(time.RFC3339),
+		Id:             uint32(item.ID),
+		Name:           item.Name,
+		Description:    item.Description,
+		Price:          item.Price,
+		CreatedAt:      item.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      item.UpdatedAt.Format(time.RFC3339),
+		ModifierGroups: groups,
+		BundleSlots:    slots,
+		OutOfStock:     item.OutOfStock,
 	}
 }
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"menu-service/database"
 	"menu-service/models"
 	"regexp"
@@ -116,7 +117,7 @@ func TestCreateMenuItem(t *testing.T) {
 			// Mock the INSERT query
 			mock.ExpectBegin()
 			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "menu_items"`)).
-				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), tt.request.Name, tt.request.Description, tt.request.Price).
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), tt.request.Name, tt.request.Description, tt.request.Price, false).
 				WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
 					AddRow(1, now, now))
 			mock.ExpectCommit()
@@ -167,6 +168,14 @@ func TestGetMenuItem(t *testing.T) {
 				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "menu_items" WHERE "menu_items"."id" = $1 AND "menu_items"."deleted_at" IS NULL ORDER BY "menu_items"."id" LIMIT $2`)).
 					WithArgs(1, 1).
 					WillReturnRows(rows)
+				// Mock bundle slots query (item is not a bundle)
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bundle_slots" WHERE "bundle_slots"."bundle_id" = $1 AND "bundle_slots"."deleted_at" IS NULL`)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "bundle_id", "name"}))
+				// Mock modifier groups query (item has no modifiers)
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "modifier_groups" WHERE "modifier_groups"."menu_item_id" = $1 AND "modifier_groups"."deleted_at" IS NULL`)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "menu_item_id", "name"}))
 			},
 			wantErr: false,
 		},
@@ -263,6 +272,12 @@ func TestGetMenu(t *testing.T) {
 
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "menu_items"`)).
 			WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bundle_slots" WHERE "bundle_slots"."bundle_id" IN ($1,$2,$3) AND "bundle_slots"."deleted_at" IS NULL`)).
+			WithArgs(1, 2, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "bundle_id", "name"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "modifier_groups" WHERE "modifier_groups"."menu_item_id" IN ($1,$2,$3) AND "modifier_groups"."deleted_at" IS NULL`)).
+			WithArgs(1, 2, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "menu_item_id", "name"}))
 
 		ctx := context.Background()
 		resp, err := server.GetMenu(ctx, &menuv1.GetMenuRequest{})
@@ -302,6 +317,275 @@ func TestGetMenu(t *testing.T) {
 	})
 }
 
+func TestGetMenuVersion(t *testing.T) {
+	db, mock, sqlDB := setupTestDB(t)
+	defer teardownTestDB(t, sqlDB)
+	database.DB = db
+
+	server := NewMenuServer()
+	tables := []string{"menu_items", "modifier_groups", "modifier_options", "bundle_slots", "bundle_choices"}
+	// tableState is the row count and latest update of one table
+	type tableState struct {
+		count   int
+		updated time.Time
+	}
+	expectVersion := func(states map[string]tableState) {
+		for _, table := range tables {
+			state := states[table]
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "` + table + `" WHERE "` + table + `"."deleted_at" IS NULL`)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(state.count))
+			rows := sqlmock.NewRows([]string{"updated_at"})
+			if !state.updated.IsZero() {
+				rows.AddRow(state.updated)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "updated_at" FROM "` + table + `" WHERE "` + table + `"."deleted_at" IS NULL ORDER BY updated_at DESC LIMIT $1`)).
+				WillReturnRows(rows)
+		}
+	}
+
+	// The version changes when an item or one of its options is added,
+	// updated or deleted
+	updated := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	item := tableState{2, updated}
+	expectVersion(nil)
+	expectVersion(map[string]tableState{"menu_items": item})
+	expectVersion(map[string]tableState{"menu_items": item})
+	expectVersion(map[string]tableState{"menu_items": {2, updated.Add(time.Second)}})
+	expectVersion(map[string]tableState{"menu_items": item, "modifier_options": {3, updated.Add(time.Second)}})
+	expectVersion(map[string]tableState{"menu_items": item, "modifier_options": {3, updated.Add(2 * time.Second)}})
+	expectVersion(map[string]tableState{"menu_items": item, "modifier_options": {2, updated.Add(2 * time.Second)}})
+
+	var versions []string
+	for i := 0; i < 7; i++ {
+		resp, err := server.GetMenuVersion(context.Background(), &menuv1.GetMenuVersionRequest{})
+		require.NoError(t, err)
+		versions = append(versions, resp.Version)
+	}
+	assert.NotEqual(t, versions[0], versions[1])
+	assert.Equal(t, versions[1], versions[2])
+	for i := 3; i < len(versions); i++ {
+		assert.NotEqual(t, versions[i-1], versions[i], "version %d", i)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "menu_items"`)).
+		WillReturnError(gorm.ErrInvalidDB)
+	_, err := server.GetMenuVersion(context.Background(), &menuv1.GetMenuVersionRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetMenuItemStock(t *testing.T) {
+	db, mock, sqlDB := setupTestDB(t)
+	defer teardownTestDB(t, sqlDB)
+	database.DB = db
+
+	server := NewMenuServer()
+
+	t.Run("marks the item out of stock", func(t *testing.T) {
+		// The item has modifiers and a bundle slot, which are read back but
+		// never written: sqlmock fails on any INSERT or other UPDATE
+		now := time.Now()
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "menu_items" SET "out_of_stock"=$1,"updated_at"=$2 WHERE id = $3 AND "menu_items"."deleted_at" IS NULL`)).
+			WithArgs(true, sqlmock.AnyArg(), 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "menu_items" WHERE "menu_items"."id" = $1 AND "menu_items"."deleted_at" IS NULL ORDER BY "menu_items"."id" LIMIT $2`)).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "name", "description", "price", "out_of_stock"}).
+				AddRow(1, now, now, nil, "Latte", "Espresso with steamed milk", 4.00, true))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bundle_slots"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "bundle_id", "name"}).
+				AddRow(5, 1, "Pastry"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bundle_choices"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slot_id", "menu_item_id", "upcharge"}).
+				AddRow(50, 5, 2, 0.0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "modifier_groups"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "menu_item_id", "name", "required", "min_selections", "max_selections"}).
+				AddRow(10, 1, "Milk", true, 1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "modifier_options"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "group_id", "name", "price_delta"}).
+				AddRow(100, 10, "Dairy", 0.0).
+				AddRow(101, 10, "Oat", 0.50))
+
+		resp, err := server.SetMenuItemStock(context.Background(), &menuv1.SetMenuItemStockRequest{Id: 1, OutOfStock: true})
+
+		require.NoError(t, err)
+		assert.True(t, resp.MenuItem.OutOfStock)
+		assert.Equal(t, "Latte", resp.MenuItem.Name)
+		require.Len(t, resp.MenuItem.ModifierGroups, 1)
+		assert.Len(t, resp.MenuItem.ModifierGroups[0].Options, 2)
+		require.Len(t, resp.MenuItem.BundleSlots, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown item", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "menu_items"`)).
+			WithArgs(false, sqlmock.AnyArg(), 99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		_, err := server.SetMenuItemStock(context.Background(), &menuv1.SetMenuItemStockRequest{Id: 99})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetMenuItem_WithModifiers(t *testing.T) {
+	// Setup
+	db, mock, sqlDB := setupTestDB(t)
+	defer teardownTestDB(t, sqlDB)
+	database.DB = db
+
+	server := NewMenuServer()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "menu_items" WHERE "menu_items"."id" = $1 AND "menu_items"."deleted_at" IS NULL ORDER BY "menu_items"."id" LIMIT $2`)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "name", "description", "price"}).
+			AddRow(1, now, now, nil, "Latte", "Espresso with steamed milk", 4.00))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bundle_slots" WHERE "bundle_slots"."bundle_id" = $1 AND "bundle_slots"."deleted_at" IS NULL`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bundle_id", "name"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "modifier_groups" WHERE "modifier_groups"."menu_item_id" = $1 AND "modifier_groups"."deleted_at" IS NULL`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "menu_item_id", "name", "required", "min_selections", "max_selections"}).
+			AddRow(10, 1, "Milk", true, 1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "modifier_options" WHERE "modifier_options"."group_id" = $1 AND "modifier_options"."deleted_at" IS NULL`)).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "group_id", "name", "price_delta"}).
+			AddRow(100, 10, "Dairy", 0.0).
+			AddRow(101, 10, "Oat", 0.50))
+
+	resp, err := server.GetMenuItem(context.Background(), &menuv1.GetMenuItemRequest{Id: 1})
+
+	require.NoError(t, err)
+	require.Len(t, resp.MenuItem.ModifierGroups, 1)
+	group := resp.MenuItem.ModifierGroups[0]
+	assert.Equal(t, "Milk", group.Name)
+	assert.True(t, group.Required)
+	assert.Equal(t, int32(1), group.MaxSelections)
+	require.Len(t, group.Options, 2)
+	assert.Equal(t, uint32(101), group.Options[1].Id)
+	assert.InDelta(t, 0.50, group.Options[1].PriceDelta, 0.001)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMenuItem_InvalidModifierGroups(t *testing.T) {
+	// Setup
+	db, mock, sqlDB := setupTestDB(t)
+	defer teardownTestDB(t, sqlDB)
+	database.DB = db
+
+	server := NewMenuServer()
+
+	option := func(name string) *menuv1.ModifierOption {
+		return &menuv1.ModifierOption{Name: name}
+	}
+
+	tests := []struct {
+		name     string
+		group    *menuv1.ModifierGroup
+		contains string
+	}{
+		{"missing name", &menuv1.ModifierGroup{Options: []*menuv1.ModifierOption{option("Small")}}, "name is required"},
+		{"no options", &menuv1.ModifierGroup{Name: "Size"}, "at least one option"},
+		{"max below min", &menuv1.ModifierGroup{Name: "Shots", MinSelections: 2, MaxSelections: 1,
+			Options: []*menuv1.ModifierOption{option("One"), option("Two")}}, "less than min_selections"},
+		{"min above options", &menuv1.ModifierGroup{Name: "Size", MinSelections: 2,
+			Options: []*menuv1.ModifierOption{option("Small")}}, "exceeds the number of options"},
+		{"unnamed option", &menuv1.ModifierGroup{Name: "Size",
+			Options: []*menuv1.ModifierOption{option("")}}, "option name is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.CreateMenuItem(context.Background(), &menuv1.CreateMenuItemRequest{
+				Name:           "Coffee",
+				Price:          3.00,
+				ModifierGroups: []*menuv1.ModifierGroup{tt.group},
+			})
+
+			require.Error(t, err)
+			assert.Nil(t, resp)
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+			assert.Contains(t, st.Message(), tt.contains)
+		})
+	}
+
+	// Validation fails before touching the database
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMenuItem_Bundle(t *testing.T) {
+	// Setup
+	db, mock, sqlDB := setupTestDB(t)
+	defer teardownTestDB(t, sqlDB)
+	database.DB = db
+
+	server := NewMenuServer()
+
+	lunchDeal := func(choiceIDs ...uint32) *menuv1.CreateMenuItemRequest {
+		slot := &menuv1.BundleSlot{Name: "Main"}
+		for _, id := range choiceIDs {
+			slot.Choices = append(slot.Choices, &menuv1.BundleChoice{MenuItemId: id})
+		}
+		return &menuv1.CreateMenuItemRequest{
+			Name:        "Lunch Deal",
+			Price:       7.50,
+			BundleSlots: []*menuv1.BundleSlot{slot},
+		}
+	}
+
+	expectChoiceLookup := func(itemRows *sqlmock.Rows, slotRows *sqlmock.Rows, args ...driver.Value) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "menu_items" WHERE "menu_items"."id" = $1 AND "menu_items"."deleted_at" IS NULL`)).
+			WillReturnRows(itemRows)
+		if slotRows == nil {
+			// GORM skips the preload when no menu items were found
+			return
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bundle_slots" WHERE "bundle_slots"."bundle_id"`)).
+			WithArgs(args...).
+			WillReturnRows(slotRows)
+	}
+
+	t.Run("empty slot", func(t *testing.T) {
+		_, err := server.CreateMenuItem(context.Background(), lunchDeal())
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "at least one choice is required")
+	})
+
+	t.Run("unknown choice", func(t *testing.T) {
+		expectChoiceLookup(sqlmock.NewRows([]string{"id", "name"}), nil)
+
+		_, err := server.CreateMenuItem(context.Background(), lunchDeal(42))
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "bundle choice menu item 42 not found")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nested bundle", func(t *testing.T) {
+		expectChoiceLookup(
+			sqlmock.NewRows([]string{"id", "name"}).AddRow(5, "Breakfast Deal"),
+			sqlmock.NewRows([]string{"id", "bundle_id", "name"}).AddRow(1, 5, "Main"),
+			5,
+		)
+
+		_, err := server.CreateMenuItem(context.Background(), lunchDeal(5))
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "menu item 5 is a bundle")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestModelToProto(t *testing.T) {
 	now := time.Now()
 	item := &models.MenuItem{
@@ -352,7 +636,7 @@ func TestPriceHandling(t *testing.T) {
 			// Mock the INSERT query
 			mock.ExpectBegin()
 			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "menu_items"`)).
-				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "Test Item", "Price test", tc.price).
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "Test Item", "Price test", tc.price, false).
 				WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
 					AddRow(1, now, now))
 			mock.ExpectCommit()
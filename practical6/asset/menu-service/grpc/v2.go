@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/douglasswm/student-cafe-common/protoconv"
+	"github.com/douglasswm/student-cafe-common/validation"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	menuv2 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v2"
+)
+
+// MenuServerV2 implements the v2 MenuService by converting to and from
+// the v1 server, so both versions share one implementation
+type MenuServerV2 struct {
+	menuv2.UnimplementedMenuServiceServer
+	v1 menuv1.MenuServiceServer
+}
+
+// NewMenuServerV2 creates a v2 menu server backed by v1
+func NewMenuServerV2(v1 menuv1.MenuServiceServer) *MenuServerV2 {
+	return &MenuServerV2{v1: v1}
+}
+
+// GetMenuItem retrieves a menu item by ID
+func (s *MenuServerV2) GetMenuItem(ctx context.Context, req *menuv2.GetMenuItemRequest) (*menuv2.GetMenuItemResponse, error) {
+	resp, err := s.v1.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: req.Id})
+	if err != nil {
+		return nil, err
+	}
+	return &menuv2.GetMenuItemResponse{MenuItem: protoconv.MenuItemToV2(resp.MenuItem)}, nil
+}
+
+// GetMenu retrieves all menu items
+func (s *MenuServerV2) GetMenu(ctx context.Context, req *menuv2.GetMenuRequest) (*menuv2.GetMenuResponse, error) {
+	resp, err := s.v1.GetMenu(ctx, &menuv1.GetMenuRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &menuv2.GetMenuResponse{MenuItems: protoconv.MenuItemsToV2(resp.MenuItems)}, nil
+}
+
+// CreateMenuItem creates a new menu item
+func (s *MenuServerV2) CreateMenuItem(ctx context.Context, req *menuv2.CreateMenuItemRequest) (*menuv2.CreateMenuItemResponse, error) {
+	v1Req, err := protoconv.CreateMenuItemRequestToV1(req)
+	if err != nil {
+		return nil, err
+	}
+	// Amounts are only range-checked by the v1 rules, e.g. price >= 0
+	if err := validation.Validate(v1Req); err != nil {
+		return nil, err
+	}
+	resp, err := s.v1.CreateMenuItem(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	return &menuv2.CreateMenuItemResponse{MenuItem: protoconv.MenuItemToV2(resp.MenuItem)}, nil
+}
+
+// GetMenuVersion returns the menu's current version
+func (s *MenuServerV2) GetMenuVersion(ctx context.Context, req *menuv2.GetMenuVersionRequest) (*menuv2.GetMenuVersionResponse, error) {
+	resp, err := s.v1.GetMenuVersion(ctx, &menuv1.GetMenuVersionRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &menuv2.GetMenuVersionResponse{Version: resp.Version}, nil
+}
+
+// SetMenuItemStock marks a menu item as in or out of stock
+func (s *MenuServerV2) SetMenuItemStock(ctx context.Context, req *menuv2.SetMenuItemStockRequest) (*menuv2.SetMenuItemStockResponse, error) {
+	resp, err := s.v1.SetMenuItemStock(ctx, &menuv1.SetMenuItemStockRequest{Id: req.Id, OutOfStock: req.OutOfStock})
+	if err != nil {
+		return nil, err
+	}
+	return &menuv2.SetMenuItemStockResponse{MenuItem: protoconv.MenuItemToV2(resp.MenuItem)}, nil
+}
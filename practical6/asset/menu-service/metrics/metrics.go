@@ -0,0 +1,29 @@
+// Package metrics defines the menu service's business metrics
+package metrics
+
+import (
+	"log/slog"
+	"menu-service/database"
+	"menu-service/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterStockMetrics registers a gauge reporting how many menu items are
+// currently out of stock. It is counted from the database on each scrape so
+// it never drifts from the stored flags.
+func RegisterStockMetrics(reg prometheus.Registerer) {
+	reg.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "cafe_menu_items_out_of_stock",
+		Help: "Number of menu items currently out of stock.",
+	}, countOutOfStock))
+}
+
+func countOutOfStock() float64 {
+	var count int64
+	if err := database.DB.Model(&models.MenuItem{}).Where("out_of_stock = ?", true).Count(&count).Error; err != nil {
+		slog.Error("Failed to count out of stock menu items", "error", err)
+		return 0
+	}
+	return float64(count)
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"menu-service/database"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestOutOfStockGauge(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB, DriverName: "postgres"}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	database.DB = db
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "menu_items" WHERE out_of_stock = $1 AND "menu_items"."deleted_at" IS NULL`)).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	reg := prometheus.NewRegistry()
+	RegisterStockMetrics(reg)
+
+	expected := `
+# HELP cafe_menu_items_out_of_stock Number of menu items currently out of stock.
+# TYPE cafe_menu_items_out_of_stock gauge
+cafe_menu_items_out_of_stock 3
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "cafe_menu_items_out_of_stock"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
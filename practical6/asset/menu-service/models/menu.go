@@ -4,14 +4,52 @@ import "gorm.io/gorm"
 
 type Menu struct {
 	gorm.Model
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
 	MenuItems   []MenuItem `json:"menu_items" gorm:"references:MenuID"`
 }
 
 type MenuItem struct {
 	gorm.Model
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	Price          float64         `json:"price"`
+	ModifierGroups []ModifierGroup `json:"modifier_groups" gorm:"foreignKey:MenuItemID"`
+	// Bundles (combo meals) have slots; Price is the fixed bundle price
+	BundleSlots []BundleSlot `json:"bundle_slots" gorm:"foreignKey:BundleID"`
+	OutOfStock  bool         `json:"out_of_stock"`
+}
+
+// ModifierGroup customises a menu item, e.g. size or milk type
+type ModifierGroup struct {
+	gorm.Model
+	MenuItemID    uint             `json:"menu_item_id"`
+	Name          string           `json:"name"`
+	Required      bool             `json:"required"`
+	MinSelections int              `json:"min_selections"`
+	MaxSelections int              `json:"max_selections"` // 0 = unlimited
+	Options       []ModifierOption `json:"options" gorm:"foreignKey:GroupID"`
+}
+
+type ModifierOption struct {
+	gorm.Model
+	GroupID    uint    `json:"group_id"`
+	Name       string  `json:"name"`
+	PriceDelta float64 `json:"price_delta"`
+}
+
+// BundleSlot is one component of a bundle, e.g. "Drink"
+type BundleSlot struct {
+	gorm.Model
+	BundleID uint           `json:"bundle_id"`
+	Name     string         `json:"name"`
+	Choices  []BundleChoice `json:"choices" gorm:"foreignKey:SlotID"`
+}
+
+// BundleChoice is a menu item allowed in a bundle slot
+type BundleChoice struct {
+	gorm.Model
+	SlotID     uint    `json:"slot_id"`
+	MenuItemID uint    `json:"menu_item_id"`
+	Upcharge   float64 `json:"upcharge"`
 }
@@ -0,0 +1,60 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"order-service/models"
+)
+
+// Result summarises a backfill run
+type Result struct {
+	// Number of order_items rows that were updated
+	Updated int64
+	// Menu item IDs that menu-service no longer knows about
+	Missing []uint
+}
+
+// ItemNames fills Name and Description on order items created before
+// they were snapshotted, looking each distinct menu item up in menu-service.
+// It is safe to run repeatedly: only rows with an empty name are touched.
+func ItemNames(ctx context.Context, db *gorm.DB, menuClient menuv1.MenuServiceClient) (*Result, error) {
+	var menuItemIDs []uint
+	if err := db.WithContext(ctx).Model(&models.OrderItem{}).
+		Where("name = ? OR name IS NULL", "").
+		Distinct().
+		Pluck("menu_item_id", &menuItemIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find order items to backfill: %w", err)
+	}
+
+	result := &Result{}
+	for _, id := range menuItemIDs {
+		resp, err := menuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: uint32(id)})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				slog.WarnContext(ctx, "backfill: menu item no longer exists, skipping", "menu_item_id", id)
+				result.Missing = append(result.Missing, id)
+				continue
+			}
+			return result, fmt.Errorf("failed to get menu item %d: %w", id, err)
+		}
+
+		tx := db.WithContext(ctx).Model(&models.OrderItem{}).
+			Where("menu_item_id = ? AND (name = ? OR name IS NULL)", id, "").
+			Updates(map[string]interface{}{
+				"name":        resp.MenuItem.Name,
+				"description": resp.MenuItem.Description,
+			})
+		if tx.Error != nil {
+			return result, fmt.Errorf("failed to update order items for menu item %d: %w", id, tx.Error)
+		}
+		result.Updated += tx.RowsAffected
+	}
+
+	return result, nil
+}
@@ -0,0 +1,65 @@
+// Command backfill-item-names fills the snapshotted name and description
+// of order items created before they were stored on the order, by looking
+// the menu items up in menu-service.
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/douglasswm/student-cafe-common/config"
+	"github.com/douglasswm/student-cafe-common/discovery"
+	"github.com/douglasswm/student-cafe-common/grpcclient"
+	"github.com/douglasswm/student-cafe-common/logging"
+	"github.com/douglasswm/student-cafe-common/mtls"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc"
+	"order-service/backfill"
+	"order-service/database"
+)
+
+func main() {
+	cfg, err := config.Load(config.Options{
+		Service: "backfill-item-names",
+		Defaults: config.Config{
+			DatabaseURL:     "host=localhost user=postgres password=postgres dbname=order_db port=5432 sslmode=disable",
+			MenuServiceAddr: "menu-service:9092",
+		},
+		Required: []string{"database_url", "menu_service_addr"},
+		Args:     os.Args[1:],
+	})
+	if err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	logging.Setup(cfg.Logging("backfill-item-names"))
+
+	// Connect also migrates, adding the name/description columns if needed
+	if err := database.Connect(cfg.DatabaseURL); err != nil {
+		logging.Fatal("Failed to connect to database", "error", err)
+	}
+
+	// Dial menu-service as order-service does, so discovery:/// addresses,
+	// deadlines and retries work here too
+	creds, err := mtls.ClientCredentials(cfg.TLS())
+	if err != nil {
+		logging.Fatal("Failed to load TLS certificates", "error", err)
+	}
+	registry, err := discovery.New(cfg.Discovery())
+	if err != nil {
+		logging.Fatal("Failed to set up service discovery", "error", err)
+	}
+	menuConn, err := grpcclient.Dial(cfg.MenuServiceAddr, grpcclient.MenuServicePolicy(),
+		grpc.WithTransportCredentials(creds), discovery.DialOption(registry))
+	if err != nil {
+		logging.Fatal("Failed to connect to menu service", "error", err)
+	}
+	defer menuConn.Close()
+
+	result, err := backfill.ItemNames(context.Background(), database.DB, menuv1.NewMenuServiceClient(menuConn))
+	if err != nil {
+		logging.Fatal("Backfill failed", "error", err)
+	}
+
+	slog.Info("Backfill complete", "updated", result.Updated, "missing", result.Missing)
+}
@@ -1,9 +1,11 @@
 package database
 
 import (
-	"log"
+	"log/slog"
 	"order-service/models"
 
+	"github.com/douglasswm/student-cafe-common/logging"
+	"github.com/douglasswm/student-cafe-common/telemetry"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -12,17 +14,36 @@ var DB *gorm.DB
 
 func Connect(dsn string) error {
 	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// Log queries through slog with the request context, so they carry the
+	// request and trace IDs
+	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logging.NewGORMLogger()})
 	if err != nil {
 		return err
 	}
 
+	// Trace queries made within a traced request
+	if err := DB.Use(telemetry.GORMPlugin{}); err != nil {
+		return err
+	}
+
 	// Only migrate order-related tables
-	err = DB.AutoMigrate(&models.Order{}, &models.OrderItem{})
+	err = DB.AutoMigrate(&models.Order{}, &models.OrderItem{}, &models.OrderItemModifier{}, &models.OrderItemComponent{}, &models.OrderRevision{}, &models.OrderRevisionItem{})
 	if err != nil {
 		return err
 	}
 
-	log.Println("Order database connected")
+	slog.Info("Order database connected")
 	return nil
 }
+
+// Close closes the database connection pool
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
@@ -0,0 +1,310 @@
+package grpc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"order-service/database"
+	"order-service/models"
+)
+
+// errOrderNotPending is returned from the amendment transaction when the
+// order left the pending state after it was loaded
+var errOrderNotPending = errors.New("order is no longer pending")
+
+// lineChange is a resolved change to one order line. Identical lines, as
+// CreateOrder allows, are changed together and merged into the first
+type lineChange struct {
+	existing []models.OrderItem
+	item     models.OrderRevisionItem
+}
+
+// AmendOrder adds, removes or changes quantities on a pending order and
+// records the changes as a new revision
+func (s *OrderServer) AmendOrder(ctx context.Context, req *orderv1.AmendOrderRequest) (*orderv1.AmendOrderResponse, error) {
+	if len(req.Changes) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one change is required")
+	}
+
+	seen := make(map[string]bool)
+	for _, change := range req.Changes {
+		if change.Quantity < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "quantity for menu item %d cannot be negative", change.MenuItemId)
+		}
+		key := changeKey(change)
+		if seen[key] {
+			if change.OrderItemId != 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "order item %d is changed more than once", change.OrderItemId)
+			}
+			return nil, status.Errorf(codes.InvalidArgument, "menu item %d is changed more than once", change.MenuItemId)
+		}
+		seen[key] = true
+	}
+
+	var order models.Order
+	if err := database.DB.WithContext(ctx).Preload("OrderItems.Modifiers").Preload("OrderItems.Components.Modifiers").First(&order, req.Id).Error; err != nil {
+		return nil, status.Errorf(codes.NotFound, "order not found")
+	}
+
+	if order.Status != models.StatusPending {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %d can no longer be amended: status is %q", order.ID, order.Status)
+	}
+
+	// Resolve each change against the current lines
+	var changes []lineChange
+	changed := make(map[uint]bool)
+	for _, change := range req.Changes {
+		lc, err := s.resolveChange(ctx, &order, change)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range lc.existing {
+			if line.ID == 0 {
+				continue
+			}
+			if changed[line.ID] {
+				return nil, status.Errorf(codes.InvalidArgument, "order item %d is changed more than once", line.ID)
+			}
+			changed[line.ID] = true
+		}
+
+		if lc.item.OldQuantity == lc.item.NewQuantity {
+			continue
+		}
+		changes = append(changes, lc)
+	}
+
+	if len(changes) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "amendment does not change the order")
+	}
+
+	// An order needs at least one item; emptying it is a cancellation
+	remaining := 0
+	for _, line := range order.OrderItems {
+		if !changed[line.ID] {
+			remaining += line.Quantity
+		}
+	}
+	for _, lc := range changes {
+		remaining += lc.item.NewQuantity
+	}
+	if remaining == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "amendment would leave order %d empty", order.ID)
+	}
+
+	revision := models.OrderRevision{
+		OrderID:  order.ID,
+		Revision: order.Revision + 1,
+	}
+
+	err := database.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bump the revision only if the order is still pending and nobody
+		// amended it concurrently
+		res := tx.Model(&models.Order{}).
+			Where("id = ? AND status = ? AND revision = ?", order.ID, models.StatusPending, order.Revision).
+			Update("revision", revision.Revision)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errOrderNotPending
+		}
+
+		for _, lc := range changes {
+			if err := applyLineChange(tx, &lc); err != nil {
+				return err
+			}
+			revision.Items = append(revision.Items, lc.item)
+		}
+
+		return tx.Create(&revision).Error
+	})
+	if errors.Is(err, errOrderNotPending) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %d was changed or started preparation, please retry", order.ID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to amend order: %v", err)
+	}
+
+	if err := preloadOrder(database.DB.WithContext(ctx)).First(&order, order.ID).Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reload order: %v", err)
+	}
+
+	return &orderv1.AmendOrderResponse{
+		Order:    modelToProto(&order),
+		Revision: revisionToProto(&revision),
+	}, nil
+}
+
+// resolveChange finds the lines a change applies to, or builds the line it
+// adds, and snapshots the line for the revision
+func (s *OrderServer) resolveChange(ctx context.Context, order *models.Order, change *orderv1.OrderItemChange) (lineChange, error) {
+	lc := lineChange{
+		item: models.OrderRevisionItem{
+			MenuItemID:  uint(change.MenuItemId),
+			NewQuantity: int(change.Quantity),
+		},
+	}
+
+	if change.OrderItemId != 0 {
+		if len(change.ModifierOptionIds) > 0 || len(change.BundleSelections) > 0 {
+			return lc, status.Errorf(codes.InvalidArgument, "modifiers and bundle selections of order item %d cannot be changed: remove it and add a new line", change.OrderItemId)
+		}
+		for _, line := range order.OrderItems {
+			if line.ID == uint(change.OrderItemId) {
+				lc.existing = []models.OrderItem{line}
+			}
+		}
+		if len(lc.existing) == 0 {
+			return lc, status.Errorf(codes.InvalidArgument, "order item %d is not on order %d", change.OrderItemId, order.ID)
+		}
+		if lc.existing[0].MenuItemID != uint(change.MenuItemId) {
+			return lc, status.Errorf(codes.InvalidArgument, "order item %d is not menu item %d", change.OrderItemId, change.MenuItemId)
+		}
+	} else {
+		key := lineKey(change.MenuItemId, change.ModifierOptionIds, change.BundleSelections)
+		for _, line := range order.OrderItems {
+			if orderItemKey(&line) == key {
+				lc.existing = append(lc.existing, line)
+			}
+		}
+	}
+
+	for _, line := range lc.existing {
+		lc.item.OldQuantity += line.Quantity
+	}
+
+	switch {
+	case len(lc.existing) > 0:
+		// Keep the line's snapshot, priced with its own modifiers and, for
+		// bundles, its components' upcharges
+		lc.item.Name = lc.existing[0].Name
+		lc.item.Price = lc.existing[0].UnitPrice()
+	case change.Quantity > 0:
+		// New line: validate the menu item, modifiers and bundle
+		// selections and snapshot their current prices
+		orderItem, err := s.buildOrderItem(ctx, &orderv1.OrderItemRequest{
+			MenuItemId:        change.MenuItemId,
+			ModifierOptionIds: change.ModifierOptionIds,
+			BundleSelections:  change.BundleSelections,
+		})
+		if err != nil {
+			return lc, err
+		}
+		orderItem.OrderID = order.ID
+		lc.item.Name = orderItem.Name
+		lc.item.Price = orderItem.UnitPrice()
+		lc.existing = []models.OrderItem{orderItem}
+	}
+
+	return lc, nil
+}
+
+// changeKey identifies the line a change applies to before the order is
+// loaded, so a request cannot change one line twice
+func changeKey(change *orderv1.OrderItemChange) string {
+	if change.OrderItemId != 0 {
+		return fmt.Sprintf("line %d", change.OrderItemId)
+	}
+	return lineKey(change.MenuItemId, change.ModifierOptionIds, change.BundleSelections)
+}
+
+// lineKey identifies a line by its menu item, chosen modifier options and
+// bundle selections
+func lineKey(menuItemID uint32, optionIDs []uint32, selections []*orderv1.BundleSelection) string {
+	key := fmt.Sprintf("item %d %v", menuItemID, sortedIDs(optionIDs))
+	selections = slices.Clone(selections)
+	slices.SortFunc(selections, func(a, b *orderv1.BundleSelection) int { return cmp.Compare(a.SlotId, b.SlotId) })
+	for _, sel := range selections {
+		key += fmt.Sprintf(" slot %d=%d %v", sel.SlotId, sel.MenuItemId, sortedIDs(sel.ModifierOptionIds))
+	}
+	return key
+}
+
+// orderItemKey is the lineKey of an existing order line
+func orderItemKey(line *models.OrderItem) string {
+	var selections []*orderv1.BundleSelection
+	for _, c := range line.Components {
+		selections = append(selections, &orderv1.BundleSelection{
+			SlotId:            uint32(c.SlotID),
+			MenuItemId:        uint32(c.MenuItemID),
+			ModifierOptionIds: modifierOptionIDs(c.Modifiers),
+		})
+	}
+	return lineKey(uint32(line.MenuItemID), modifierOptionIDs(line.Modifiers), selections)
+}
+
+func modifierOptionIDs(modifiers []models.OrderItemModifier) []uint32 {
+	ids := make([]uint32, len(modifiers))
+	for i, m := range modifiers {
+		ids[i] = uint32(m.ModifierOptionID)
+	}
+	return ids
+}
+
+func sortedIDs(ids []uint32) []uint32 {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return ids
+}
+
+// applyLineChange writes the new quantity for one line and records the
+// line's ID on the revision item
+func applyLineChange(tx *gorm.DB, lc *lineChange) error {
+	first, rest := lc.existing[0], lc.existing[1:]
+
+	for _, line := range rest {
+		if err := tx.Delete(&line).Error; err != nil {
+			return err
+		}
+	}
+
+	var err error
+	switch {
+	case lc.item.NewQuantity == 0:
+		err = tx.Delete(&first).Error
+	case first.ID == 0:
+		first.Quantity = lc.item.NewQuantity
+		err = tx.Create(&first).Error
+	default:
+		err = tx.Model(&first).Update("quantity", lc.item.NewQuantity).Error
+	}
+	lc.item.OrderItemID = first.ID
+	return err
+}
+
+// preloadOrder loads an order together with its items and revision history
+func preloadOrder(db *gorm.DB) *gorm.DB {
+	return db.Preload("OrderItems.Modifiers").Preload("OrderItems.Components.Modifiers").Preload("Revisions.Items")
+}
+
+// revisionToProto converts a GORM OrderRevision model to proto OrderRevision message
+func revisionToProto(revision *models.OrderRevision) *orderv1.OrderRevision {
+	items := make([]*orderv1.OrderRevisionItem, len(revision.Items))
+	for i, item := range revision.Items {
+		items[i] = &orderv1.OrderRevisionItem{
+			MenuItemId:  uint32(item.MenuItemID),
+			Name:        item.Name,
+			OldQuantity: int32(item.OldQuantity),
+			NewQuantity: int32(item.NewQuantity),
+			Price:       item.Price,
+			OrderItemId: uint32(item.OrderItemID),
+		}
+	}
+
+	return &orderv1.OrderRevision{
+		Id:        uint32(revision.ID),
+		OrderId:   uint32(revision.OrderID),
+		Revision:  int32(revision.Revision),
+		Items:     items,
+		CreatedAt: revision.CreatedAt.Format(time.RFC3339),
+	}
+}
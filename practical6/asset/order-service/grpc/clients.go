@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/douglasswm/student-cafe-common/grpcclient"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
 	"google.golang.org/grpc"
@@ -30,8 +31,9 @@ func NewClients() (*Clients, error) {
 	}
 
 	// Connect to user service
-	userConn, err := grpc.NewClient(
+	userConn, err := grpcclient.Dial(
 		userServiceAddr,
+		grpcclient.UserServicePolicy(),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	)
 	if err != nil {
@@ -39,8 +41,9 @@ func NewClients() (*Clients, error) {
 	}
 
 	// Connect to menu service
-	menuConn, err := grpc.NewClient(
+	menuConn, err := grpcclient.Dial(
 		menuServiceAddr,
+		grpcclient.MenuServicePolicy(),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	)
 	if err != nil {
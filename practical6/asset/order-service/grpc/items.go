@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"order-service/models"
+)
+
+// buildOrderItem validates a requested item against the menu service and
+// snapshots its price, name, modifiers and bundle components
+func (s *OrderServer) buildOrderItem(ctx context.Context, req *orderv1.OrderItemRequest) (models.OrderItem, error) {
+	menuItemResp, err := s.MenuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: req.MenuItemId})
+	if status.Code(err) == codes.NotFound {
+		return models.OrderItem{}, status.Errorf(codes.InvalidArgument, "menu item %d not found: %v", req.MenuItemId, err)
+	}
+	if err != nil {
+		return models.OrderItem{}, err
+	}
+	menuItem := menuItemResp.MenuItem
+	if menuItem.OutOfStock {
+		return models.OrderItem{}, status.Errorf(codes.FailedPrecondition, "menu item %d (%s) is out of stock", req.MenuItemId, menuItem.Name)
+	}
+
+	modifiers, err := resolveModifiers(menuItem, req.ModifierOptionIds)
+	if err != nil {
+		return models.OrderItem{}, status.Errorf(codes.InvalidArgument, "invalid modifiers for menu item %d: %v", req.MenuItemId, err)
+	}
+
+	components, err := s.resolveComponents(ctx, menuItem, req.BundleSelections)
+	if err != nil {
+		return models.OrderItem{}, err
+	}
+
+	return models.OrderItem{
+		MenuItemID:  uint(req.MenuItemId),
+		Quantity:    int(req.Quantity),
+		Price:       menuItem.Price,
+		Name:        menuItem.Name,
+		Description: menuItem.Description,
+		Modifiers:   modifiers,
+		Components:  components,
+	}, nil
+}
+
+// resolveComponents validates one selection per bundle slot against the
+// slot's allowed choices and snapshots each chosen item for the kitchen.
+// Errors are gRPC statuses
+func (s *OrderServer) resolveComponents(ctx context.Context, item *menuv1.MenuItem, selections []*orderv1.BundleSelection) ([]models.OrderItemComponent, error) {
+	invalid := func(format string, args ...any) error {
+		return status.Errorf(codes.InvalidArgument, "invalid bundle selections for menu item %d: %s", item.Id, fmt.Sprintf(format, args...))
+	}
+
+	if len(item.BundleSlots) == 0 {
+		if len(selections) > 0 {
+			return nil, invalid("%q is not a bundle", item.Name)
+		}
+		return nil, nil
+	}
+
+	slots := make(map[uint32]*menuv1.BundleSlot)
+	for _, slot := range item.BundleSlots {
+		slots[slot.Id] = slot
+	}
+
+	chosen := make(map[uint32]*orderv1.BundleSelection)
+	for _, sel := range selections {
+		if _, ok := slots[sel.SlotId]; !ok {
+			return nil, invalid("bundle slot %d does not belong to %q", sel.SlotId, item.Name)
+		}
+		if _, ok := chosen[sel.SlotId]; ok {
+			return nil, invalid("bundle slot %d selected more than once", sel.SlotId)
+		}
+		chosen[sel.SlotId] = sel
+	}
+
+	// Check every slot has an allowed choice before fetching any components
+	upcharges := make(map[uint32]float64)
+	for _, slot := range item.BundleSlots {
+		sel, ok := chosen[slot.Id]
+		if !ok {
+			return nil, invalid("%q requires a %q selection", item.Name, slot.Name)
+		}
+		allowed := false
+		for _, c := range slot.Choices {
+			if c.MenuItemId == sel.MenuItemId {
+				allowed = true
+				upcharges[slot.Id] = c.Upcharge
+				break
+			}
+		}
+		if !allowed {
+			return nil, invalid("menu item %d is not a %q choice", sel.MenuItemId, slot.Name)
+		}
+	}
+
+	// Build components in slot order so the kitchen sees a stable layout
+	var components []models.OrderItemComponent
+	for _, slot := range item.BundleSlots {
+		sel := chosen[slot.Id]
+		componentResp, err := s.MenuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: sel.MenuItemId})
+		if status.Code(err) == codes.NotFound {
+			return nil, invalid("menu item %d not found: %v", sel.MenuItemId, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if componentResp.MenuItem.OutOfStock {
+			return nil, status.Errorf(codes.FailedPrecondition, "%q choice %d (%s) is out of stock", slot.Name, sel.MenuItemId, componentResp.MenuItem.Name)
+		}
+
+		modifiers, err := resolveModifiers(componentResp.MenuItem, sel.ModifierOptionIds)
+		if err != nil {
+			return nil, invalid("%v", err)
+		}
+
+		components = append(components, models.OrderItemComponent{
+			SlotID:     uint(slot.Id),
+			SlotName:   slot.Name,
+			MenuItemID: uint(sel.MenuItemId),
+			Name:       componentResp.MenuItem.Name,
+			Upcharge:   upcharges[slot.Id],
+			Modifiers:  modifiers,
+		})
+	}
+
+	return components, nil
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"fmt"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"order-service/models"
+)
+
+// resolveModifiers validates the chosen modifier options against the menu
+// item's modifier groups and snapshots them for the order item
+func resolveModifiers(item *menuv1.MenuItem, optionIDs []uint32) ([]models.OrderItemModifier, error) {
+	type choice struct {
+		group  *menuv1.ModifierGroup
+		option *menuv1.ModifierOption
+	}
+	options := make(map[uint32]choice)
+	for _, group := range item.ModifierGroups {
+		for _, option := range group.Options {
+			options[option.Id] = choice{group: group, option: option}
+		}
+	}
+
+	var modifiers []models.OrderItemModifier
+	selected := make(map[uint32]int)
+	seen := make(map[uint32]bool)
+	for _, id := range optionIDs {
+		c, ok := options[id]
+		if !ok {
+			return nil, fmt.Errorf("modifier option %d is not available for %q", id, item.Name)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("modifier option %d selected more than once", id)
+		}
+		seen[id] = true
+		selected[c.group.Id]++
+
+		modifiers = append(modifiers, models.OrderItemModifier{
+			ModifierOptionID: uint(id),
+			GroupName:        c.group.Name,
+			Name:             c.option.Name,
+			PriceDelta:       c.option.PriceDelta,
+		})
+	}
+
+	for _, group := range item.ModifierGroups {
+		minSelections := int(group.MinSelections)
+		if group.Required && minSelections < 1 {
+			minSelections = 1
+		}
+		count := selected[group.Id]
+		if count < minSelections {
+			return nil, fmt.Errorf("%q requires at least %d %q selection(s)", item.Name, minSelections, group.Name)
+		}
+		if group.MaxSelections > 0 && count > int(group.MaxSelections) {
+			return nil, fmt.Errorf("%q allows at most %d %q selection(s)", item.Name, group.MaxSelections, group.Name)
+		}
+	}
+
+	return modifiers, nil
+}
@@ -2,18 +2,23 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/douglasswm/student-cafe-common/grpcclient"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 	"order-service/database"
+	"order-service/metrics"
 	"order-service/models"
+	"order-service/receipt"
 )
 
 // OrderServer implements the gRPC OrderService
@@ -21,23 +26,31 @@ type OrderServer struct {
 	orderv1.UnimplementedOrderServiceServer
 	UserClient userv1.UserServiceClient
 	MenuClient menuv1.MenuServiceClient
+	// Connections behind the clients, used for health checks
+	UserConn *grpcclient.Conn
+	MenuConn *grpcclient.Conn
 }
 
-// NewOrderServer creates a new gRPC order server
-func NewOrderServer(userServiceAddr, menuServiceAddr string) (*OrderServer, error) {
+// NewOrderServer creates a new gRPC order server whose clients use creds
+// and any further dial options to reach the user and menu services
+func NewOrderServer(userServiceAddr, menuServiceAddr string, creds credentials.TransportCredentials, opts ...grpc.DialOption) (*OrderServer, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, opts...)
+
 	// Connect to user service
-	userConn, err := grpc.NewClient(
+	userConn, err := grpcclient.Dial(
 		userServiceAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpcclient.UserServicePolicy(),
+		opts...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to user service: %w", err)
 	}
 
 	// Connect to menu service
-	menuConn, err := grpc.NewClient(
+	menuConn, err := grpcclient.Dial(
 		menuServiceAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpcclient.MenuServicePolicy(),
+		opts...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to menu service: %w", err)
@@ -46,42 +59,53 @@ func NewOrderServer(userServiceAddr, menuServiceAddr string) (*OrderServer, erro
 	return &OrderServer{
 		UserClient: userv1.NewUserServiceClient(userConn),
 		MenuClient: menuv1.NewMenuServiceClient(menuConn),
+		UserConn:   userConn,
+		MenuConn:   menuConn,
 	}, nil
 }
 
+// Close closes the connections to the user and menu services
+func (s *OrderServer) Close() {
+	for _, conn := range []*grpcclient.Conn{s.UserConn, s.MenuConn} {
+		if conn != nil {
+			conn.Close()
+		}
+	}
+}
+
 // CreateOrder creates a new order
 func (s *OrderServer) CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest) (*orderv1.CreateOrderResponse, error) {
 	// Validate user exists via gRPC
+	// Only a missing user makes the request invalid; other errors, such as
+	// an unavailable user-service, are passed on as they are
 	_, err := s.UserClient.GetUser(ctx, &userv1.GetUserRequest{Id: req.UserId})
-	if err != nil {
+	if status.Code(err) == codes.NotFound {
 		return nil, status.Errorf(codes.InvalidArgument, "user not found: %v", err)
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	// Create order
 	order := models.Order{
 		UserID: uint(req.UserId),
-		Status: "pending",
+		Status: models.StatusPending,
 	}
 
 	// Validate menu items and snapshot prices via gRPC
 	for _, item := range req.Items {
-		menuItemResp, err := s.MenuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: item.MenuItemId})
+		orderItem, err := s.buildOrderItem(ctx, item)
 		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "menu item %d not found: %v", item.MenuItemId, err)
-		}
-
-		orderItem := models.OrderItem{
-			MenuItemID: uint(item.MenuItemId),
-			Quantity:   int(item.Quantity),
-			Price:      menuItemResp.MenuItem.Price,
+			return nil, err
 		}
 		order.OrderItems = append(order.OrderItems, orderItem)
 	}
 
 	// Save order to database
-	if err := database.DB.Create(&order).Error; err != nil {
+	if err := database.DB.WithContext(ctx).Create(&order).Error; err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create order: %v", err)
 	}
+	metrics.RecordOrderCreated(&order)
 
 	return &orderv1.CreateOrderResponse{
 		Order: modelToProto(&order),
@@ -91,7 +115,7 @@ func (s *OrderServer) CreateOrder(ctx context.Context, req *orderv1.CreateOrderR
 // GetOrders retrieves all orders
 func (s *OrderServer) GetOrders(ctx context.Context, req *orderv1.GetOrdersRequest) (*orderv1.GetOrdersResponse, error) {
 	var orders []models.Order
-	if err := database.DB.Preload("OrderItems").Find(&orders).Error; err != nil {
+	if err := preloadOrder(database.DB.WithContext(ctx)).Find(&orders).Error; err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get orders: %v", err)
 	}
 
@@ -108,7 +132,7 @@ func (s *OrderServer) GetOrders(ctx context.Context, req *orderv1.GetOrdersReque
 // GetOrder retrieves an order by ID
 func (s *OrderServer) GetOrder(ctx context.Context, req *orderv1.GetOrderRequest) (*orderv1.GetOrderResponse, error) {
 	var order models.Order
-	if err := database.DB.Preload("OrderItems").First(&order, req.Id).Error; err != nil {
+	if err := preloadOrder(database.DB.WithContext(ctx)).First(&order, req.Id).Error; err != nil {
 		return nil, status.Errorf(codes.NotFound, "order not found")
 	}
 
@@ -117,21 +141,104 @@ func (s *OrderServer) GetOrder(ctx context.Context, req *orderv1.GetOrderRequest
 	}, nil
 }
 
+// GetOrderReceipt renders an itemised receipt for an order
+func (s *OrderServer) GetOrderReceipt(ctx context.Context, req *orderv1.GetOrderReceiptRequest) (*orderv1.GetOrderReceiptResponse, error) {
+	var order models.Order
+	if err := preloadOrder(database.DB.WithContext(ctx)).First(&order, req.Id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+
+	rcpt := receipt.Build(&order, s.menuItemNames(ctx, order.OrderItems))
+
+	switch req.Format {
+	case orderv1.ReceiptFormat_RECEIPT_FORMAT_UNSPECIFIED, orderv1.ReceiptFormat_RECEIPT_FORMAT_TEXT:
+		return &orderv1.GetOrderReceiptResponse{
+			Content:     rcpt.Text(),
+			ContentType: "text/plain; charset=utf-8",
+			Filename:    rcpt.Filename("txt"),
+		}, nil
+	case orderv1.ReceiptFormat_RECEIPT_FORMAT_HTML:
+		content, err := rcpt.HTML()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to render receipt: %v", err)
+		}
+		return &orderv1.GetOrderReceiptResponse{
+			Content:     content,
+			ContentType: "text/html; charset=utf-8",
+			Filename:    rcpt.Filename("html"),
+		}, nil
+	case orderv1.ReceiptFormat_RECEIPT_FORMAT_PDF:
+		return &orderv1.GetOrderReceiptResponse{
+			Content:     rcpt.PDF(),
+			ContentType: "application/pdf",
+			Filename:    rcpt.Filename("pdf"),
+		}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported receipt format: %v", req.Format)
+	}
+}
+
+// menuItemNames resolves display names via gRPC for items created before
+// names were snapshotted onto OrderItem. Items that can no longer be found
+// (e.g. deleted) are left out of the map.
+func (s *OrderServer) menuItemNames(ctx context.Context, items []models.OrderItem) map[uint]string {
+	names := make(map[uint]string)
+	for _, item := range items {
+		if item.Name != "" {
+			continue
+		}
+		if _, ok := names[item.MenuItemID]; ok {
+			continue
+		}
+		resp, err := s.MenuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: uint32(item.MenuItemID)})
+		if err != nil {
+			continue
+		}
+		names[item.MenuItemID] = resp.MenuItem.Name
+	}
+	return names
+}
+
 // modelToProto converts a GORM Order model to proto Order message
 func modelToProto(order *models.Order) *orderv1.Order {
 	protoItems := make([]*orderv1.OrderItem, len(order.OrderItems))
 	for i, item := range order.OrderItems {
+		components := make([]*orderv1.OrderItemComponent, len(item.Components))
+		for j, c := range item.Components {
+			components[j] = &orderv1.OrderItemComponent{
+				SlotId:     uint32(c.SlotID),
+				SlotName:   c.SlotName,
+				MenuItemId: uint32(c.MenuItemID),
+				Name:       c.Name,
+				Upcharge:   c.Upcharge,
+				Modifiers:  modifiersToProto(c.Modifiers),
+			}
+		}
+
 		protoItems[i] = &orderv1.OrderItem{
-			Id:         uint32(item.ID),
-			OrderId:    uint32(item.OrderID),
-			MenuItemId: uint32(item.MenuItemID),
-			Quantity:   int32(item.Quantity),
-			Price:      item.Price,
-			CreatedAt:  item.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:  item.UpdatedAt.Format(time.RFC3339),
+			Id:          uint32(item.ID),
+			OrderId:     uint32(item.OrderID),
+			MenuItemId:  uint32(item.MenuItemID),
+			Quantity:    int32(item.Quantity),
+			Price:       item.Price,
+			CreatedAt:   item.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:   item.UpdatedAt.Format(time.RFC3339),
+			Name:        item.Name,
+			Description: item.Description,
+			Modifiers:   modifiersToProto(item.Modifiers),
+			LineTotal:   item.LineTotal(),
+			Components:  components,
 		}
 	}
 
+	protoRevisions := make([]*orderv1.OrderRevision, len(order.Revisions))
+	for i := range order.Revisions {
+		protoRevisions[i] = revisionToProto(&order.Revisions[i])
+	}
+
 	return &orderv1.Order{
 		Id:         uint32(order.ID),
 		UserId:     uint32(order.UserID),
@@ -139,5 +246,21 @@ func modelToProto(order *models.Order) *orderv1.Order {
 		OrderItems: protoItems,
 		CreatedAt:  order.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:  order.UpdatedAt.Format(time.RFC3339),
+		Revision:   int32(order.Revision),
+		Revisions:  protoRevisions,
+	}
+}
+
+// modifiersToProto converts GORM OrderItemModifier models to proto messages
+func modifiersToProto(modifiers []models.OrderItemModifier) []*orderv1.OrderItemModifier {
+	protoModifiers := make([]*orderv1.OrderItemModifier, len(modifiers))
+	for i, m := range modifiers {
+		protoModifiers[i] = &orderv1.OrderItemModifier{
+			ModifierOptionId: uint32(m.ModifierOptionID),
+			GroupName:        m.GroupName,
+			Name:             m.Name,
+			PriceDelta:       m.PriceDelta,
+		}
 	}
+	return protoModifiers
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"order-service/database"
+	"order-service/metrics"
 	"order-service/models"
 	"regexp"
 	"testing"
@@ -13,11 +14,14 @@ import (
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -82,6 +86,22 @@ func (m *MockMenuServiceClient) CreateMenuItem(ctx context.Context, req *menuv1.
 	return args.Get(0).(*menuv1.CreateMenuItemResponse), args.Error(1)
 }
 
+func (m *MockMenuServiceClient) GetMenuVersion(ctx context.Context, req *menuv1.GetMenuVersionRequest, opts ...grpc.CallOption) (*menuv1.GetMenuVersionResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.GetMenuVersionResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) SetMenuItemStock(ctx context.Context, req *menuv1.SetMenuItemStockRequest, opts ...grpc.CallOption) (*menuv1.SetMenuItemStockResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.SetMenuItemStockResponse), args.Error(1)
+}
+
 // setupTestDB creates a mock database for testing
 func setupTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, *sql.DB) {
 	sqlDB, mock, err := sqlmock.New()
@@ -107,6 +127,13 @@ func teardownTestDB(t *testing.T, sqlDB *sql.DB) {
 	sqlDB.Close()
 }
 
+// orderValueSum returns the sum of all observed order values
+func orderValueSum(t *testing.T) float64 {
+	var m dto.Metric
+	require.NoError(t, metrics.OrderValue.Write(&m))
+	return m.GetHistogram().GetSampleSum()
+}
+
 func TestCreateOrder_Success(t *testing.T) {
 	// Setup
 	db, dbMock, sqlDB := setupTestDB(t)
@@ -143,7 +170,7 @@ func TestCreateOrder_Success(t *testing.T) {
 	dbMock.ExpectBegin()
 	// Mock INSERT for order
 	dbMock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "orders"`)).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "pending").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "pending", 0).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
 			AddRow(1, now, now))
 	// Mock INSERT for order items (uses QUERY not EXEC because of RETURNING clause)
@@ -152,6 +179,8 @@ func TestCreateOrder_Success(t *testing.T) {
 	dbMock.ExpectCommit()
 
 	// Test
+	ordersBefore := testutil.ToFloat64(metrics.OrdersCreated)
+	valueBefore := orderValueSum(t)
 	ctx := context.Background()
 	resp, err := server.CreateOrder(ctx, &orderv1.CreateOrderRequest{
 		UserId: 1,
@@ -173,12 +202,17 @@ func TestCreateOrder_Success(t *testing.T) {
 	assert.Equal(t, uint32(1), resp.Order.OrderItems[0].MenuItemId)
 	assert.Equal(t, int32(2), resp.Order.OrderItems[0].Quantity)
 	assert.InDelta(t, 2.50, resp.Order.OrderItems[0].Price, 0.001)
+	assert.Equal(t, "Coffee", resp.Order.OrderItems[0].Name)
 
 	// Verify second item
 	assert.Equal(t, uint32(2), resp.Order.OrderItems[1].MenuItemId)
 	assert.Equal(t, int32(1), resp.Order.OrderItems[1].Quantity)
 	assert.InDelta(t, 2.00, resp.Order.OrderItems[1].Price, 0.001)
 
+	// Verify business metrics were recorded
+	assert.Equal(t, ordersBefore+1, testutil.ToFloat64(metrics.OrdersCreated))
+	assert.InDelta(t, 7.00, orderValueSum(t)-valueBefore, 0.001)
+
 	mockUserClient.AssertExpectations(t)
 	mockMenuClient.AssertExpectations(t)
 	assert.NoError(t, dbMock.ExpectationsWereMet())
@@ -222,6 +256,68 @@ func TestCreateOrder_InvalidUser(t *testing.T) {
 	mockUserClient.AssertExpectations(t)
 }
 
+func TestCreateOrder_DependencyErrorsPassThrough(t *testing.T) {
+	user := &userv1.GetUserResponse{User: &userv1.User{Id: 1, Name: "Test User"}}
+	bundle := &menuv1.GetMenuItemResponse{MenuItem: &menuv1.MenuItem{Id: 2, Name: "Breakfast Deal", Price: 6.00, BundleSlots: []*menuv1.BundleSlot{
+		{Id: 1, Name: "Pastry", Choices: []*menuv1.BundleChoice{{MenuItemId: 1}}},
+	}}}
+
+	tests := []struct {
+		name  string
+		setup func(users *MockUserServiceClient, menu *MockMenuServiceClient)
+		item  *orderv1.OrderItemRequest
+		want  codes.Code
+	}{
+		{
+			name: "user-service unavailable",
+			setup: func(users *MockUserServiceClient, menu *MockMenuServiceClient) {
+				users.On("GetUser", mock.Anything, mock.Anything).Return(nil, status.Error(codes.Unavailable, "circuit breaker is open"))
+			},
+			item: &orderv1.OrderItemRequest{MenuItemId: 1, Quantity: 1},
+			want: codes.Unavailable,
+		},
+		{
+			name: "menu-service timed out",
+			setup: func(users *MockUserServiceClient, menu *MockMenuServiceClient) {
+				users.On("GetUser", mock.Anything, mock.Anything).Return(user, nil)
+				menu.On("GetMenuItem", mock.Anything, mock.Anything).Return(nil, status.Error(codes.DeadlineExceeded, "deadline exceeded"))
+			},
+			item: &orderv1.OrderItemRequest{MenuItemId: 1, Quantity: 1},
+			want: codes.DeadlineExceeded,
+		},
+		{
+			name: "bundle component unavailable",
+			setup: func(users *MockUserServiceClient, menu *MockMenuServiceClient) {
+				users.On("GetUser", mock.Anything, mock.Anything).Return(user, nil)
+				menu.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 2}).Return(bundle, nil)
+				menu.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 1}).Return(nil, status.Error(codes.Unavailable, "circuit breaker is open"))
+			},
+			item: &orderv1.OrderItemRequest{MenuItemId: 2, Quantity: 1, BundleSelections: []*orderv1.BundleSelection{{SlotId: 1, MenuItemId: 1}}},
+			want: codes.Unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sqlMock, sqlDB := setupTestDB(t)
+			defer teardownTestDB(t, sqlDB)
+			database.DB = db
+
+			mockUserClient := new(MockUserServiceClient)
+			mockMenuClient := new(MockMenuServiceClient)
+			tt.setup(mockUserClient, mockMenuClient)
+			server := &OrderServer{UserClient: mockUserClient, MenuClient: mockMenuClient}
+
+			_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+				UserId: 1,
+				Items:  []*orderv1.OrderItemRequest{tt.item},
+			})
+			assert.Equal(t, tt.want, status.Code(err), "a failing dependency must not be reported as an invalid request")
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestCreateOrder_InvalidMenuItem(t *testing.T) {
 	// Setup
 	db, _, sqlDB := setupTestDB(t)
@@ -267,6 +363,53 @@ func TestCreateOrder_InvalidMenuItem(t *testing.T) {
 	mockMenuClient.AssertExpectations(t)
 }
 
+func TestCreateOrder_OutOfStock(t *testing.T) {
+	db, sqlMock, sqlDB := setupTestDB(t)
+	defer teardownTestDB(t, sqlDB)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1, Name: "Test User"}}, nil)
+	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 1}).
+		Return(&menuv1.GetMenuItemResponse{MenuItem: &menuv1.MenuItem{Id: 1, Name: "Croissant", OutOfStock: true}}, nil)
+	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 2}).
+		Return(&menuv1.GetMenuItemResponse{MenuItem: &menuv1.MenuItem{Id: 2, Name: "Breakfast Deal", Price: 6.00, BundleSlots: []*menuv1.BundleSlot{
+			{Id: 1, Name: "Pastry", Choices: []*menuv1.BundleChoice{{MenuItemId: 1}}},
+		}}}, nil)
+
+	t.Run("item", func(t *testing.T) {
+		_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+			UserId: 1,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 1}},
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Contains(t, err.Error(), "Croissant) is out of stock")
+	})
+
+	t.Run("bundle component", func(t *testing.T) {
+		_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+			UserId: 1,
+			Items: []*orderv1.OrderItemRequest{{MenuItemId: 2, Quantity: 1, BundleSelections: []*orderv1.BundleSelection{
+				{SlotId: 1, MenuItemId: 1},
+			}}},
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Contains(t, err.Error(), `"Pastry" choice 1 (Croissant) is out of stock`)
+	})
+
+	// No order is written
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 func TestCreateOrder_DatabaseError(t *testing.T) {
 	// Setup
 	db, dbMock, sqlDB := setupTestDB(t)
@@ -300,6 +443,7 @@ func TestCreateOrder_DatabaseError(t *testing.T) {
 	dbMock.ExpectRollback()
 
 	// Test
+	ordersBefore := testutil.ToFloat64(metrics.OrdersCreated)
 	ctx := context.Background()
 	resp, err := server.CreateOrder(ctx, &orderv1.CreateOrderRequest{
 		UserId: 1,
@@ -315,6 +459,7 @@ func TestCreateOrder_DatabaseError(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, codes.Internal, st.Code())
 	assert.Contains(t, st.Message(), "failed to create order")
+	assert.Equal(t, ordersBefore, testutil.ToFloat64(metrics.OrdersCreated), "failed orders are not counted")
 
 	mockUserClient.AssertExpectations(t)
 	mockMenuClient.AssertExpectations(t)
@@ -359,6 +504,18 @@ func TestGetOrder(t *testing.T) {
 				dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_items" WHERE "order_items"."order_id" = $1 AND "order_items"."deleted_at" IS NULL`)).
 					WithArgs(1).
 					WillReturnRows(itemRows)
+				// Mock bundle components query (not a bundle)
+				dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_item_components" WHERE "order_item_components"."order_item_id" = $1 AND "order_item_components"."deleted_at" IS NULL`)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_item_id"}))
+				// Mock item modifiers query (no modifiers chosen)
+				dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_item_modifiers" WHERE "order_item_modifiers"."order_item_id" = $1 AND "order_item_modifiers"."deleted_at" IS NULL`)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_item_id"}))
+				// Mock revision history query (no amendments yet)
+				dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_revisions" WHERE "order_revisions"."order_id" = $1 AND "order_revisions"."deleted_at" IS NULL`)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "revision"}))
 			},
 			wantErr: false,
 		},
@@ -446,6 +603,15 @@ func TestGetOrders(t *testing.T) {
 		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_items" WHERE "order_items"."order_id" IN ($1,$2) AND "order_items"."deleted_at" IS NULL`)).
 			WithArgs(1, 2).
 			WillReturnRows(itemRows)
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_item_components" WHERE "order_item_components"."order_item_id" IN ($1,$2) AND "order_item_components"."deleted_at" IS NULL`)).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_item_id"}))
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_item_modifiers" WHERE "order_item_modifiers"."order_item_id" IN ($1,$2) AND "order_item_modifiers"."deleted_at" IS NULL`)).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_item_id"}))
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_revisions" WHERE "order_revisions"."order_id" IN ($1,$2) AND "order_revisions"."deleted_at" IS NULL`)).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "revision"}))
 
 		ctx := context.Background()
 		resp, err := server.GetOrders(ctx, &orderv1.GetOrdersRequest{})
@@ -482,6 +648,379 @@ func TestGetOrders(t *testing.T) {
 	})
 }
 
+func TestGetOrderReceipt(t *testing.T) {
+	// Setup
+	db, dbMock, sqlDB := setupTestDB(t)
+	defer teardownTestDB(t, sqlDB)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	// Menu item 1 still exists, menu item 2 has been deleted
+	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 1}).
+		Return(&menuv1.GetMenuItemResponse{
+			MenuItem: &menuv1.MenuItem{Id: 1, Name: "Coffee", Price: 3.00},
+		}, nil)
+	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 2}).
+		Return(nil, status.Errorf(codes.NotFound, "menu item not found"))
+
+	expectOrder := func(id uint32) {
+		now := time.Now()
+		orderRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "user_id", "status"}).
+			AddRow(id, now, now, nil, 1, "completed")
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "orders" WHERE "orders"."id" = $1 AND "orders"."deleted_at" IS NULL ORDER BY "orders"."id" LIMIT $2`)).
+			WithArgs(id, 1).
+			WillReturnRows(orderRows)
+		itemRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "order_id", "menu_item_id", "quantity", "price"}).
+			AddRow(1, now, now, nil, id, 1, 2, 2.50).
+			AddRow(2, now, now, nil, id, 2, 1, 4.00)
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_items" WHERE "order_items"."order_id" = $1 AND "order_items"."deleted_at" IS NULL`)).
+			WithArgs(id).
+			WillReturnRows(itemRows)
+		modifierRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "order_item_id", "modifier_option_id", "group_name", "name", "price_delta"}).
+			AddRow(1, now, now, nil, 1, 100, "Milk", "Oat milk", 0.50)
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_item_components" WHERE "order_item_components"."order_item_id" IN ($1,$2) AND "order_item_components"."deleted_at" IS NULL`)).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_item_id"}))
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_item_modifiers" WHERE "order_item_modifiers"."order_item_id" IN ($1,$2) AND "order_item_modifiers"."deleted_at" IS NULL`)).
+			WithArgs(1, 2).
+			WillReturnRows(modifierRows)
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_revisions" WHERE "order_revisions"."order_id" = $1 AND "order_revisions"."deleted_at" IS NULL`)).
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "revision"}))
+	}
+
+	tests := []struct {
+		name        string
+		format      orderv1.ReceiptFormat
+		contentType string
+		filename    string
+		contains    []string
+	}{
+		{
+			name:        "plain text by default",
+			format:      orderv1.ReceiptFormat_RECEIPT_FORMAT_UNSPECIFIED,
+			contentType: "text/plain; charset=utf-8",
+			filename:    "receipt-order-1.txt",
+			contains:    []string{"Receipt for order #1", "Coffee", "+ Oat milk", "Menu item #2", "6.00", "10.00"},
+		},
+		{
+			name:        "html",
+			format:      orderv1.ReceiptFormat_RECEIPT_FORMAT_HTML,
+			contentType: "text/html; charset=utf-8",
+			filename:    "receipt-order-1.html",
+			contains:    []string{"<td>Coffee<br><small>+ Oat milk (0.50)</small></td>", "10.00"},
+		},
+		{
+			name:        "pdf",
+			format:      orderv1.ReceiptFormat_RECEIPT_FORMAT_PDF,
+			contentType: "application/pdf",
+			filename:    "receipt-order-1.pdf",
+			contains:    []string{"%PDF-1.4", "Coffee", "%%EOF"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectOrder(1)
+
+			ctx := context.Background()
+			resp, err := server.GetOrderReceipt(ctx, &orderv1.GetOrderReceiptRequest{
+				Id:     1,
+				Format: tt.format,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.contentType, resp.ContentType)
+			assert.Equal(t, tt.filename, resp.Filename)
+			for _, want := range tt.contains {
+				assert.Contains(t, string(resp.Content), want)
+			}
+			assert.NoError(t, dbMock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("non-existent order", func(t *testing.T) {
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "orders" WHERE "orders"."id" = $1 AND "orders"."deleted_at" IS NULL ORDER BY "orders"."id" LIMIT $2`)).
+			WithArgs(9999, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		ctx := context.Background()
+		resp, err := server.GetOrderReceipt(ctx, &orderv1.GetOrderReceiptRequest{Id: 9999})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.NoError(t, dbMock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "orders" WHERE "orders"."id" = $1 AND "orders"."deleted_at" IS NULL ORDER BY "orders"."id" LIMIT $2`)).
+			WithArgs(1, 1).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		resp, err := server.GetOrderReceipt(context.Background(), &orderv1.GetOrderReceiptRequest{Id: 1})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.NoError(t, dbMock.ExpectationsWereMet())
+	})
+}
+
+func TestAmendOrder_Validation(t *testing.T) {
+	// Setup
+	db, dbMock, sqlDB := setupTestDB(t)
+	defer teardownTestDB(t, sqlDB)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	tests := []struct {
+		name        string
+		request     *orderv1.AmendOrderRequest
+		expectedErr codes.Code
+		contains    string
+	}{
+		{
+			name:        "no changes",
+			request:     &orderv1.AmendOrderRequest{Id: 1},
+			expectedErr: codes.InvalidArgument,
+			contains:    "at least one change is required",
+		},
+		{
+			name: "negative quantity",
+			request: &orderv1.AmendOrderRequest{Id: 1, Changes: []*orderv1.OrderItemChange{
+				{MenuItemId: 1, Quantity: -1},
+			}},
+			expectedErr: codes.InvalidArgument,
+			contains:    "cannot be negative",
+		},
+		{
+			name: "duplicate menu item",
+			request: &orderv1.AmendOrderRequest{Id: 1, Changes: []*orderv1.OrderItemChange{
+				{MenuItemId: 1, Quantity: 1},
+				{MenuItemId: 1, Quantity: 2},
+			}},
+			expectedErr: codes.InvalidArgument,
+			contains:    "changed more than once",
+		},
+		{
+			name: "duplicate order item",
+			request: &orderv1.AmendOrderRequest{Id: 1, Changes: []*orderv1.OrderItemChange{
+				{MenuItemId: 1, OrderItemId: 5, Quantity: 1},
+				{MenuItemId: 1, OrderItemId: 5, Quantity: 0},
+			}},
+			expectedErr: codes.InvalidArgument,
+			contains:    "order item 5 is changed more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.AmendOrder(context.Background(), tt.request)
+
+			require.Error(t, err)
+			assert.Nil(t, resp)
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedErr, st.Code())
+			assert.Contains(t, st.Message(), tt.contains)
+		})
+	}
+
+	t.Run("preparation already started", func(t *testing.T) {
+		now := time.Now()
+		orderRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "user_id", "status", "revision"}).
+			AddRow(1, now, now, nil, 1, "preparing", 0)
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "orders" WHERE "orders"."id" = $1 AND "orders"."deleted_at" IS NULL ORDER BY "orders"."id" LIMIT $2`)).
+			WithArgs(1, 1).
+			WillReturnRows(orderRows)
+		itemRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "order_id", "menu_item_id", "quantity", "price"}).
+			AddRow(1, now, now, nil, 1, 1, 1, 2.50)
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_items" WHERE "order_items"."order_id" = $1 AND "order_items"."deleted_at" IS NULL`)).
+			WithArgs(1).
+			WillReturnRows(itemRows)
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_item_components" WHERE "order_item_components"."order_item_id" = $1 AND "order_item_components"."deleted_at" IS NULL`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_item_id"}))
+		dbMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "order_item_modifiers" WHERE "order_item_modifiers"."order_item_id" = $1 AND "order_item_modifiers"."deleted_at" IS NULL`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_item_id", "modifier_option_id"}))
+
+		resp, err := server.AmendOrder(context.Background(), &orderv1.AmendOrderRequest{
+			Id:      1,
+			Changes: []*orderv1.OrderItemChange{{MenuItemId: 2, Quantity: 1}},
+		})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+		assert.NoError(t, dbMock.ExpectationsWereMet())
+		mockMenuClient.AssertNotCalled(t, "GetMenuItem", mock.Anything, mock.Anything)
+	})
+}
+
+func TestResolveModifiers(t *testing.T) {
+	coffee := &menuv1.MenuItem{
+		Id:    1,
+		Name:  "Coffee",
+		Price: 3.00,
+		ModifierGroups: []*menuv1.ModifierGroup{
+			{Id: 1, Name: "Size", Required: true, MaxSelections: 1, Options: []*menuv1.ModifierOption{
+				{Id: 10, Name: "Regular"},
+				{Id: 11, Name: "Large", PriceDelta: 0.80},
+			}},
+			{Id: 2, Name: "Extra shots", MaxSelections: 2, Options: []*menuv1.ModifierOption{
+				{Id: 20, Name: "Shot one", PriceDelta: 0.60},
+				{Id: 21, Name: "Shot two", PriceDelta: 0.60},
+				{Id: 22, Name: "Shot three", PriceDelta: 0.60},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		optionIDs  []uint32
+		wantErr    string
+		wantDeltas float64
+	}{
+		{name: "required group only", optionIDs: []uint32{10}},
+		{name: "with priced options", optionIDs: []uint32{11, 20, 21}, wantDeltas: 2.00},
+		{name: "missing required group", optionIDs: []uint32{20}, wantErr: `requires at least 1 "Size"`},
+		{name: "too many in group", optionIDs: []uint32{10, 11}, wantErr: `allows at most 1 "Size"`},
+		{name: "too many shots", optionIDs: []uint32{10, 20, 21, 22}, wantErr: `allows at most 2 "Extra shots"`},
+		{name: "unknown option", optionIDs: []uint32{10, 99}, wantErr: "modifier option 99 is not available"},
+		{name: "duplicate option", optionIDs: []uint32{10, 20, 20}, wantErr: "selected more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modifiers, err := resolveModifiers(coffee, tt.optionIDs)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, modifiers, len(tt.optionIDs))
+			item := models.OrderItem{Price: coffee.Price, Quantity: 2, Modifiers: modifiers}
+			assert.InDelta(t, coffee.Price+tt.wantDeltas, item.UnitPrice(), 0.001)
+			assert.InDelta(t, 2*(coffee.Price+tt.wantDeltas), item.LineTotal(), 0.001)
+		})
+	}
+}
+
+func TestCreateOrder_InvalidModifiers(t *testing.T) {
+	// Setup
+	db, dbMock, sqlDB := setupTestDB(t)
+	defer teardownTestDB(t, sqlDB)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1}}, nil)
+	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 1}).
+		Return(&menuv1.GetMenuItemResponse{
+			MenuItem: &menuv1.MenuItem{Id: 1, Name: "Coffee", Price: 3.00, ModifierGroups: []*menuv1.ModifierGroup{
+				{Id: 1, Name: "Milk", Required: true, MaxSelections: 1, Options: []*menuv1.ModifierOption{{Id: 10, Name: "Oat"}}},
+			}},
+		}, nil)
+
+	resp, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 1}},
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Contains(t, st.Message(), "invalid modifiers for menu item 1")
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestResolveComponents(t *testing.T) {
+	lunchDeal := &menuv1.MenuItem{Id: 1, Name: "Lunch Deal", Price: 8.00, BundleSlots: []*menuv1.BundleSlot{
+		{Id: 1, Name: "Sandwich", Choices: []*menuv1.BundleChoice{{MenuItemId: 10}, {MenuItemId: 11, Upcharge: 1.00}}},
+		{Id: 2, Name: "Drink", Choices: []*menuv1.BundleChoice{{MenuItemId: 20}}},
+	}}
+
+	mockMenuClient := new(MockMenuServiceClient)
+	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 11}).
+		Return(&menuv1.GetMenuItemResponse{MenuItem: &menuv1.MenuItem{Id: 11, Name: "Club Sandwich"}}, nil)
+	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 20}).
+		Return(&menuv1.GetMenuItemResponse{MenuItem: &menuv1.MenuItem{Id: 20, Name: "Latte", ModifierGroups: []*menuv1.ModifierGroup{
+			{Id: 1, Name: "Milk", MaxSelections: 1, Options: []*menuv1.ModifierOption{{Id: 100, Name: "Oat milk", PriceDelta: 0.50}}},
+		}}}, nil)
+	server := &OrderServer{MenuClient: mockMenuClient}
+
+	t.Run("valid selections", func(t *testing.T) {
+		components, err := server.resolveComponents(context.Background(), lunchDeal, []*orderv1.BundleSelection{
+			{SlotId: 2, MenuItemId: 20, ModifierOptionIds: []uint32{100}},
+			{SlotId: 1, MenuItemId: 11},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, components, 2)
+		assert.Equal(t, "Sandwich", components[0].SlotName)
+		assert.Equal(t, "Club Sandwich", components[0].Name)
+		assert.Equal(t, 1.00, components[0].Upcharge)
+		assert.Equal(t, "Latte", components[1].Name)
+		require.Len(t, components[1].Modifiers, 1)
+
+		item := models.OrderItem{Price: lunchDeal.Price, Quantity: 2, Components: components}
+		assert.InDelta(t, 9.50, item.UnitPrice(), 0.001)
+		assert.InDelta(t, 19.00, item.LineTotal(), 0.001)
+	})
+
+	errTests := []struct {
+		name       string
+		item       *menuv1.MenuItem
+		selections []*orderv1.BundleSelection
+		wantErr    string
+	}{
+		{"missing slot", lunchDeal, []*orderv1.BundleSelection{{SlotId: 1, MenuItemId: 10}}, `requires a "Drink" selection`},
+		{"unknown slot", lunchDeal, []*orderv1.BundleSelection{{SlotId: 9, MenuItemId: 10}}, "bundle slot 9 does not belong"},
+		{"slot selected twice", lunchDeal, []*orderv1.BundleSelection{{SlotId: 1, MenuItemId: 10}, {SlotId: 1, MenuItemId: 11}}, "selected more than once"},
+		{"choice not allowed", lunchDeal, []*orderv1.BundleSelection{{SlotId: 1, MenuItemId: 20}, {SlotId: 2, MenuItemId: 20}}, `menu item 20 is not a "Sandwich" choice`},
+		{"selections on plain item", &menuv1.MenuItem{Id: 5, Name: "Coffee"}, []*orderv1.BundleSelection{{SlotId: 1, MenuItemId: 10}}, "is not a bundle"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.resolveComponents(context.Background(), tt.item, tt.selections)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestModelToProto(t *testing.T) {
 	now := time.Now()
 	order := &models.Order{
@@ -499,10 +1038,12 @@ func TestModelToProto(t *testing.T) {
 					CreatedAt: now,
 					UpdatedAt: now,
 				},
-				OrderID:    1,
-				MenuItemID: 2,
-				Quantity:   3,
-				Price:      4.50,
+				OrderID:     1,
+				MenuItemID:  2,
+				Quantity:    3,
+				Price:       4.50,
+				Name:        "Latte",
+				Description: "Espresso with milk",
 			},
 		},
 	}
@@ -522,6 +1063,8 @@ func TestModelToProto(t *testing.T) {
 	assert.Equal(t, uint32(2), protoOrder.OrderItems[0].MenuItemId)
 	assert.Equal(t, int32(3), protoOrder.OrderItems[0].Quantity)
 	assert.InDelta(t, 4.50, protoOrder.OrderItems[0].Price, 0.001)
+	assert.Equal(t, "Latte", protoOrder.OrderItems[0].Name)
+	assert.Equal(t, "Espresso with milk", protoOrder.OrderItems[0].Description)
 }
 
 func TestCreateOrder_PriceSnapshot(t *testing.T) {
@@ -555,7 +1098,7 @@ func TestCreateOrder_PriceSnapshot(t *testing.T) {
 	now := time.Now()
 	dbMock.ExpectBegin()
 	dbMock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "orders"`)).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "pending").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "pending", 0).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
 			AddRow(1, now, now))
 	dbMock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "order_items"`)).
@@ -582,7 +1125,7 @@ func TestCreateOrder_PriceSnapshot(t *testing.T) {
 func TestNewOrderServer(t *testing.T) {
 	// Test successful creation (will create connections even though services aren't running)
 	t.Run("creates server successfully", func(t *testing.T) {
-		server, err := NewOrderServer("localhost:9091", "localhost:9092")
+		server, err := NewOrderServer("localhost:9091", "localhost:9092", insecure.NewCredentials())
 
 		// The connections will be created successfully even without running services
 		// because grpc.NewClient doesn't immediately connect
@@ -595,7 +1138,7 @@ func TestNewOrderServer(t *testing.T) {
 	// Test with invalid address format to trigger user service connection error
 	t.Run("invalid user service address", func(t *testing.T) {
 		// Use an invalid scheme that will cause connection failure
-		server, err := NewOrderServer("://invalid-address", "localhost:9092")
+		server, err := NewOrderServer("://invalid-address", "localhost:9092", insecure.NewCredentials())
 
 		// This should succeed with current gRPC implementation
 		// because grpc.NewClient is lazy and doesn't validate immediately
@@ -605,7 +1148,7 @@ func TestNewOrderServer(t *testing.T) {
 
 	// Test with invalid menu service address
 	t.Run("invalid menu service address", func(t *testing.T) {
-		server, err := NewOrderServer("localhost:9091", "://invalid-address")
+		server, err := NewOrderServer("localhost:9091", "://invalid-address", insecure.NewCredentials())
 
 		// This should succeed with current gRPC implementation
 		_ = server
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/douglasswm/student-cafe-common/protoconv"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	orderv2 "github.com/douglasswm/student-cafe-protos/gen/go/order/v2"
+)
+
+// OrderServerV2 implements the v2 OrderService by converting to and from
+// the v1 server, so both versions share one implementation
+type OrderServerV2 struct {
+	orderv2.UnimplementedOrderServiceServer
+	v1 orderv1.OrderServiceServer
+}
+
+// NewOrderServerV2 creates a v2 order server backed by v1
+func NewOrderServerV2(v1 orderv1.OrderServiceServer) *OrderServerV2 {
+	return &OrderServerV2{v1: v1}
+}
+
+// CreateOrder creates a new order
+func (s *OrderServerV2) CreateOrder(ctx context.Context, req *orderv2.CreateOrderRequest) (*orderv2.CreateOrderResponse, error) {
+	resp, err := s.v1.CreateOrder(ctx, protoconv.CreateOrderRequestToV1(req))
+	if err != nil {
+		return nil, err
+	}
+	return &orderv2.CreateOrderResponse{Order: protoconv.OrderToV2(resp.Order)}, nil
+}
+
+// GetOrders retrieves all orders
+func (s *OrderServerV2) GetOrders(ctx context.Context, req *orderv2.GetOrdersRequest) (*orderv2.GetOrdersResponse, error) {
+	resp, err := s.v1.GetOrders(ctx, &orderv1.GetOrdersRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &orderv2.GetOrdersResponse{Orders: protoconv.OrdersToV2(resp.Orders)}, nil
+}
+
+// GetOrder retrieves an order by ID
+func (s *OrderServerV2) GetOrder(ctx context.Context, req *orderv2.GetOrderRequest) (*orderv2.GetOrderResponse, error) {
+	resp, err := s.v1.GetOrder(ctx, &orderv1.GetOrderRequest{Id: req.Id})
+	if err != nil {
+		return nil, err
+	}
+	return &orderv2.GetOrderResponse{Order: protoconv.OrderToV2(resp.Order)}, nil
+}
+
+// GetOrderReceipt renders an itemised receipt for an order
+func (s *OrderServerV2) GetOrderReceipt(ctx context.Context, req *orderv2.GetOrderReceiptRequest) (*orderv2.GetOrderReceiptResponse, error) {
+	resp, err := s.v1.GetOrderReceipt(ctx, &orderv1.GetOrderReceiptRequest{Id: req.Id, Format: protoconv.ReceiptFormatToV1(req.Format)})
+	if err != nil {
+		return nil, err
+	}
+	return &orderv2.GetOrderReceiptResponse{Content: resp.Content, ContentType: resp.ContentType, Filename: resp.Filename}, nil
+}
+
+// AmendOrder changes the line items of a pending order
+func (s *OrderServerV2) AmendOrder(ctx context.Context, req *orderv2.AmendOrderRequest) (*orderv2.AmendOrderResponse, error) {
+	resp, err := s.v1.AmendOrder(ctx, protoconv.AmendOrderRequestToV1(req))
+	if err != nil {
+		return nil, err
+	}
+	return &orderv2.AmendOrderResponse{
+		Order:    protoconv.OrderToV2(resp.Order),
+		Revision: protoconv.OrderRevisionToV2(resp.Revision),
+	}, nil
+}
@@ -1,63 +1,175 @@
 package main
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"log/slog"
 	"net"
-	"os"
 	"order-service/database"
 	grpcserver "order-service/grpc"
+	ordermetrics "order-service/metrics"
+	"os"
+	"time"
 
+	"github.com/douglasswm/student-cafe-common/config"
+	"github.com/douglasswm/student-cafe-common/discovery"
+	"github.com/douglasswm/student-cafe-common/health"
+	"github.com/douglasswm/student-cafe-common/lifecycle"
+	"github.com/douglasswm/student-cafe-common/logging"
+	"github.com/douglasswm/student-cafe-common/metrics"
+	"github.com/douglasswm/student-cafe-common/mtls"
+	"github.com/douglasswm/student-cafe-common/telemetry"
+	"github.com/douglasswm/student-cafe-common/validation"
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	orderv2 "github.com/douglasswm/student-cafe-protos/gen/go/order/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// Connect to dedicated order database
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		dsn = "host=localhost user=postgres password=postgres dbname=order_db port=5432 sslmode=disable"
+	// Load configuration from env, an optional YAML file and flags
+	configOpts := config.Options{
+		Service: "order-service",
+		Defaults: config.Config{
+			GRPCPort:        "9093",
+			AdminPort:       "9103",
+			DatabaseURL:     "host=localhost user=postgres password=postgres dbname=order_db port=5432 sslmode=disable",
+			UserServiceAddr: "user-service:9091",
+			MenuServiceAddr: "menu-service:9092",
+		},
+		Required: []string{"grpc_port", "admin_port", "database_url", "user_service_addr", "menu_service_addr"},
+		Args:     os.Args[1:],
 	}
+	cfg, err := config.Load(configOpts)
+	if err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+
+	// JSON logs tagged with the service name
+	logger := logging.Setup(cfg.Logging("order-service"))
+	slog.Info("Configuration loaded", "config", cfg)
+
+	// Cancelled on SIGINT/SIGTERM to start a graceful shutdown
+	ctx, stop := lifecycle.SignalContext()
+	defer stop()
 
-	if err := database.Connect(dsn); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	// Apply log level and timeout changes without a restart
+	reloader := config.NewReloader(configOpts, cfg)
+	reloader.OnChange(config.ApplyLogLevel)
+	go reloader.Watch(ctx, config.DefaultWatchInterval)
+
+	// Set up tracing before anything that makes calls
+	shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.ConfigFromEnv("order-service"))
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", "error", err)
 	}
+	defer shutdownTracing(context.Background())
 
-	// Get gRPC port from environment
-	grpcPort := os.Getenv("GRPC_PORT")
-	if grpcPort == "" {
-		grpcPort = "9093"
+	// Connect to dedicated order database
+	if err := database.Connect(cfg.DatabaseURL); err != nil {
+		logging.Fatal("Failed to connect to database", "error", err)
 	}
 
 	// Start listening on TCP port
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
 	if err != nil {
-		log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
+		logging.Fatal("Failed to listen on gRPC port", "port", cfg.GRPCPort, "error", err)
 	}
 
-	// Get service addresses for gRPC clients (order service calls user and menu)
-	userServiceAddr := os.Getenv("USER_SERVICE_GRPC_ADDR")
-	if userServiceAddr == "" {
-		userServiceAddr = "user-service:9091"
+	// The registry this service announces itself in and, when configured,
+	// looks up discovery:/// addresses in
+	registry, err := discovery.New(cfg.Discovery())
+	if err != nil {
+		logging.Fatal("Failed to set up service discovery", "error", err)
 	}
 
-	menuServiceAddr := os.Getenv("MENU_SERVICE_GRPC_ADDR")
-	if menuServiceAddr == "" {
-		menuServiceAddr = "menu-service:9092"
+	// Present our certificate to the services we call when mutual TLS is configured
+	clientCreds, err := mtls.ClientCredentials(cfg.TLS())
+	if err != nil {
+		logging.Fatal("Failed to load TLS certificates", "error", err)
 	}
 
 	// Create order gRPC server with clients to other services
-	orderServer, err := grpcserver.NewOrderServer(userServiceAddr, menuServiceAddr)
+	orderServer, err := grpcserver.NewOrderServer(cfg.UserServiceAddr, cfg.MenuServiceAddr, clientCreds, discovery.DialOption(registry))
 	if err != nil {
-		log.Fatalf("Failed to create gRPC order server: %v", err)
+		logging.Fatal("Failed to create gRPC order server", "error", err)
+	}
+
+	// Serve metrics on a separate admin port
+	ordermetrics.Register(prometheus.DefaultRegisterer)
+	adminServer, err := metrics.ServeAdmin(":"+cfg.AdminPort, metrics.NewAdminMux(prometheus.DefaultGatherer))
+	if err != nil {
+		logging.Fatal("Failed to start admin server", "port", cfg.AdminPort, "error", err)
+	}
+
+	// Require callers to present a certificate when mutual TLS is configured
+	creds, err := mtls.ServerCredentials(cfg.TLS())
+	if err != nil {
+		logging.Fatal("Failed to load TLS certificates", "error", err)
 	}
 
 	// Create and register gRPC server
-	s := grpc.NewServer()
+	grpcMetrics := metrics.NewGRPCServerMetrics(prometheus.DefaultRegisterer)
+	opts := []grpc.ServerOption{grpc.Creds(creds), telemetry.ServerOption()}
+	opts = append(opts, grpcMetrics.ServerOptions()...)
+	opts = append(opts, logging.ServerOptions(logger)...)
+	opts = append(opts, validation.ServerOption())
+	s := grpc.NewServer(opts...)
 	orderv1.RegisterOrderServiceServer(s, orderServer)
+	orderv2.RegisterOrderServiceServer(s, grpcserver.NewOrderServerV2(orderServer))
+
+	// Let tools such as grpcurl list and call the services without the proto files
+	reflection.Register(s)
+
+	// Report SERVING only while the database and the services we call are healthy
+	healthMonitor := health.NewMonitor(
+		[]string{orderv1.OrderService_ServiceDesc.ServiceName, orderv2.OrderService_ServiceDesc.ServiceName},
+		health.DBCheck(database.DB),
+		health.GRPCCheck("user-service", orderServer.UserConn.HealthConn()),
+		health.GRPCCheck("menu-service", orderServer.MenuConn.HealthConn()),
+	)
+	healthMonitor.Register(s)
+	go healthMonitor.Run(ctx)
+
+	slog.Info("Order service (gRPC only) starting", "port", cfg.GRPCPort)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			logging.Fatal("gRPC server failed", "error", err)
+		}
+	}()
+
+	// List this instance in the registry while its health checks pass
+	var registration *discovery.Registration
+	if registry != nil {
+		instance, err := discovery.NewInstance("order-service", cfg.Advertise())
+		if err != nil {
+			logging.Fatal("Invalid advertise address", "error", err)
+		}
+		if registration, err = discovery.Register(ctx, registry, instance, healthMonitor.Serving); err != nil {
+			logging.Fatal("Failed to register with service discovery", "error", err)
+		}
+	}
+
+	<-ctx.Done()
+	slog.Info("Shutting down order-service")
+
+	// Leave the registry first so new connections go to other instances
+	if registration != nil {
+		deregisterCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := registration.Deregister(deregisterCtx); err != nil {
+			slog.Error("Failed to deregister from service discovery", "error", err)
+		}
+		cancel()
+	}
+
+	// Tell clients to stop sending new requests, then let in-flight ones finish
+	healthMonitor.Shutdown()
+	lifecycle.GracefulStop(s, reloader.Current().ShutdownTimeout)
 
-	log.Printf("Order service (gRPC only) starting on :%s", grpcPort)
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("gRPC server failed: %v", err)
+	adminServer.Close()
+	orderServer.Close()
+	if err := database.Close(); err != nil {
+		slog.Error("Failed to close database", "error", err)
 	}
+	slog.Info("Order service stopped")
 }
@@ -0,0 +1,34 @@
+// Package metrics defines the order service's business metrics
+package metrics
+
+import (
+	"order-service/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// OrdersCreated counts orders successfully placed
+	OrdersCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "cafe_orders_created_total",
+		Help: "Total number of orders created.",
+	})
+
+	// OrderValue records the total value of each order placed
+	OrderValue = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "cafe_order_value",
+		Help:    "Total value of orders created.",
+		Buckets: []float64{2.5, 5, 10, 15, 20, 30, 50, 100},
+	})
+)
+
+// Register registers the order metrics with reg
+func Register(reg prometheus.Registerer) {
+	reg.MustRegister(OrdersCreated, OrderValue)
+}
+
+// RecordOrderCreated records a newly created order
+func RecordOrderCreated(order *models.Order) {
+	OrdersCreated.Inc()
+	OrderValue.Observe(order.Total())
+}
@@ -2,11 +2,29 @@ package models
 
 import "gorm.io/gorm"
 
+// Order statuses
+const (
+	StatusPending   = "pending"
+	StatusPreparing = "preparing"
+	StatusCompleted = "completed"
+)
+
 type Order struct {
 	gorm.Model
-	UserID     uint        `json:"user_id"`
-	Status     string      `json:"status"` // "pending", "completed"
-	OrderItems []OrderItem `json:"order_items" gorm:"foreignKey:OrderID"`
+	UserID     uint            `json:"user_id"`
+	Status     string          `json:"status"` // "pending", "preparing", "completed"
+	Revision   int             `json:"revision"`
+	OrderItems []OrderItem     `json:"order_items" gorm:"foreignKey:OrderID"`
+	Revisions  []OrderRevision `json:"revisions" gorm:"foreignKey:OrderID"`
+}
+
+// Total is the sum of the order's line totals
+func (o *Order) Total() float64 {
+	var total float64
+	for i := range o.OrderItems {
+		total += o.OrderItems[i].LineTotal()
+	}
+	return total
 }
 
 type OrderItem struct {
@@ -15,4 +33,76 @@ type OrderItem struct {
 	MenuItemID uint    `json:"menu_item_id"`
 	Quantity   int     `json:"quantity"`
 	Price      float64 `json:"price"` // Snapshot price at order time
+	// Snapshot of the menu item at order time, so renamed or deleted
+	// items still show what was bought
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Modifiers   []OrderItemModifier `json:"modifiers" gorm:"foreignKey:OrderItemID"`
+	// Chosen components when the item is a bundle; Price is the bundle price
+	Components []OrderItemComponent `json:"components" gorm:"foreignKey:OrderItemID"`
+}
+
+// UnitPrice is the snapshotted item price including modifier deltas and,
+// for bundles, component upcharges and component modifier deltas
+func (i *OrderItem) UnitPrice() float64 {
+	price := i.Price
+	for _, m := range i.Modifiers {
+		price += m.PriceDelta
+	}
+	for _, c := range i.Components {
+		price += c.Upcharge
+		for _, m := range c.Modifiers {
+			price += m.PriceDelta
+		}
+	}
+	return price
+}
+
+// LineTotal is the unit price multiplied by the quantity
+func (i *OrderItem) LineTotal() float64 {
+	return i.UnitPrice() * float64(i.Quantity)
+}
+
+// OrderItemComponent is the menu item chosen for one slot of a bundle,
+// snapshotted so the kitchen sees what to prepare
+type OrderItemComponent struct {
+	gorm.Model
+	OrderItemID uint                `json:"order_item_id"`
+	SlotID      uint                `json:"slot_id"`
+	SlotName    string              `json:"slot_name"`
+	MenuItemID  uint                `json:"menu_item_id"`
+	Name        string              `json:"name"`
+	Upcharge    float64             `json:"upcharge"`
+	Modifiers   []OrderItemModifier `json:"modifiers" gorm:"foreignKey:ComponentID"`
+}
+
+// OrderItemModifier is a modifier option chosen for an order item (or for
+// a bundle component), snapshotted from the menu at order time
+type OrderItemModifier struct {
+	gorm.Model
+	OrderItemID      uint    `json:"order_item_id"`
+	ComponentID      uint    `json:"component_id"`
+	ModifierOptionID uint    `json:"modifier_option_id"`
+	GroupName        string  `json:"group_name"`
+	Name             string  `json:"name"`
+	PriceDelta       float64 `json:"price_delta"`
+}
+
+// OrderRevision records the line changes made by one AmendOrder call
+type OrderRevision struct {
+	gorm.Model
+	OrderID  uint                `json:"order_id"`
+	Revision int                 `json:"revision"`
+	Items    []OrderRevisionItem `json:"items" gorm:"foreignKey:RevisionID"`
+}
+
+type OrderRevisionItem struct {
+	gorm.Model
+	RevisionID  uint    `json:"revision_id"`
+	OrderItemID uint    `json:"order_item_id"`
+	MenuItemID  uint    `json:"menu_item_id"`
+	Name        string  `json:"name"`
+	OldQuantity int     `json:"old_quantity"`
+	NewQuantity int     `json:"new_quantity"`
+	Price       float64 `json:"price"`
 }
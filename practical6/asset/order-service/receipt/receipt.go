@@ -0,0 +1,207 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"order-service/models"
+)
+
+// Width of the plain text receipt in characters
+const lineWidth = 44
+
+// Line is a single itemised entry on a receipt. UnitPrice includes the
+// price deltas of the chosen modifiers and bundle components.
+type Line struct {
+	MenuItemID uint
+	Name       string
+	Quantity   int
+	UnitPrice  float64
+	LineTotal  float64
+	Modifiers  []Modifier
+	Components []Component
+}
+
+// Component is an item chosen for a bundle, listed under the bundle line
+type Component struct {
+	Name      string
+	Upcharge  float64
+	Modifiers []Modifier
+}
+
+// Modifier is a customisation listed under its receipt line
+type Modifier struct {
+	Name       string
+	PriceDelta float64
+}
+
+// Receipt is the rendered view of an order
+type Receipt struct {
+	OrderID   uint
+	UserID    uint
+	Status    string
+	CreatedAt time.Time
+	Lines     []Line
+	Total     float64
+}
+
+// Build creates a receipt from an order using the snapshotted item names and prices.
+// names maps menu item IDs to display names for items without a snapshotted
+// name; missing entries fall back to the ID.
+func Build(order *models.Order, names map[uint]string) *Receipt {
+	r := &Receipt{
+		OrderID:   order.ID,
+		UserID:    order.UserID,
+		Status:    order.Status,
+		CreatedAt: order.CreatedAt,
+	}
+
+	for _, item := range order.OrderItems {
+		name := item.Name
+		if name == "" {
+			name = names[item.MenuItemID]
+		}
+		if name == "" {
+			name = fmt.Sprintf("Menu item #%d", item.MenuItemID)
+		}
+
+		line := Line{
+			MenuItemID: item.MenuItemID,
+			Name:       name,
+			Quantity:   item.Quantity,
+			UnitPrice:  item.UnitPrice(),
+			LineTotal:  item.LineTotal(),
+		}
+		for _, m := range item.Modifiers {
+			line.Modifiers = append(line.Modifiers, Modifier{Name: m.Name, PriceDelta: m.PriceDelta})
+		}
+		for _, c := range item.Components {
+			component := Component{Name: c.Name, Upcharge: c.Upcharge}
+			for _, m := range c.Modifiers {
+				component.Modifiers = append(component.Modifiers, Modifier{Name: m.Name, PriceDelta: m.PriceDelta})
+			}
+			line.Components = append(line.Components, component)
+		}
+		r.Lines = append(r.Lines, line)
+		r.Total += line.LineTotal
+	}
+
+	return r
+}
+
+// Filename returns a download name for the receipt with the given extension
+func (r *Receipt) Filename(ext string) string {
+	return fmt.Sprintf("receipt-order-%d.%s", r.OrderID, ext)
+}
+
+// textLines lays out the receipt as fixed-width lines (shared by text and PDF output)
+func (r *Receipt) textLines() []string {
+	rule := strings.Repeat("-", lineWidth)
+	lines := []string{
+		center("STUDENT CAFE"),
+		center(fmt.Sprintf("Receipt for order #%d", r.OrderID)),
+		"",
+		fmt.Sprintf("Date:     %s", r.CreatedAt.Format("2006-01-02 15:04")),
+		fmt.Sprintf("Customer: #%d", r.UserID),
+		fmt.Sprintf("Status:   %s", r.Status),
+		rule,
+		fmt.Sprintf("%-4s%-22s%9s%9s", "Qty", "Item", "Unit", "Total"),
+		rule,
+	}
+
+	for _, l := range r.Lines {
+		lines = append(lines, fmt.Sprintf("%-4d%-22s%9.2f%9.2f", l.Quantity, truncate(l.Name, 21), l.UnitPrice, l.LineTotal))
+		for _, m := range l.Modifiers {
+			lines = append(lines, fmt.Sprintf("    + %-20s%+9.2f", truncate(m.Name, 19), m.PriceDelta))
+		}
+		for _, c := range l.Components {
+			if c.Upcharge != 0 {
+				lines = append(lines, fmt.Sprintf("    * %-20s%+9.2f", truncate(c.Name, 19), c.Upcharge))
+			} else {
+				lines = append(lines, fmt.Sprintf("    * %s", truncate(c.Name, 38)))
+			}
+			for _, m := range c.Modifiers {
+				lines = append(lines, fmt.Sprintf("      + %-18s%+9.2f", truncate(m.Name, 17), m.PriceDelta))
+			}
+		}
+	}
+
+	lines = append(lines,
+		rule,
+		fmt.Sprintf("%-26s%18.2f", "TOTAL", r.Total),
+		"",
+		center("Thank you!"),
+	)
+	return lines
+}
+
+// Text renders the receipt as plain text
+func (r *Receipt) Text() []byte {
+	return []byte(strings.Join(r.textLines(), "\n") + "\n")
+}
+
+var htmlTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"money": func(v float64) string { return fmt.Sprintf("%.2f", v) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Receipt for order #{{.OrderID}}</title>
+<style>
+body { font-family: sans-serif; max-width: 480px; margin: 2em auto; }
+table { width: 100%; border-collapse: collapse; }
+th, td { padding: 4px; border-bottom: 1px solid #ddd; }
+td.num, th.num { text-align: right; }
+tfoot td { font-weight: bold; border-bottom: none; }
+</style>
+</head>
+<body>
+<h1>Student Cafe</h1>
+<p>Receipt for order #{{.OrderID}}<br>
+Date: {{.CreatedAt.Format "2006-01-02 15:04"}}<br>
+Customer: #{{.UserID}}<br>
+Status: {{.Status}}</p>
+<table>
+<thead><tr><th>Qty</th><th>Item</th><th class="num">Unit</th><th class="num">Total</th></tr></thead>
+<tbody>
+{{- range .Lines}}
+<tr><td>{{.Quantity}}</td><td>{{.Name}}{{range .Modifiers}}<br><small>+ {{.Name}} ({{money .PriceDelta}})</small>{{end}}{{range .Components}}<br><small>&bull; {{.Name}}{{if .Upcharge}} ({{money .Upcharge}}){{end}}{{range .Modifiers}}<br>&nbsp;&nbsp;+ {{.Name}} ({{money .PriceDelta}}){{end}}</small>{{end}}</td><td class="num">{{money .UnitPrice}}</td><td class="num">{{money .LineTotal}}</td></tr>
+{{- end}}
+</tbody>
+<tfoot><tr><td colspan="3">Total</td><td class="num">{{money .Total}}</td></tr></tfoot>
+</table>
+</body>
+</html>
+`))
+
+// HTML renders the receipt as a standalone HTML page
+func (r *Receipt) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// PDF renders the receipt as a PDF document
+func (r *Receipt) PDF() []byte {
+	return renderPDF(r.textLines())
+}
+
+func center(s string) string {
+	if len(s) >= lineWidth {
+		return s
+	}
+	return strings.Repeat(" ", (lineWidth-len(s))/2) + s
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "~"
+}
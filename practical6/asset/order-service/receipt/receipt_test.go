@@ -0,0 +1,144 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func testOrder(items int) *models.Order {
+	order := &models.Order{
+		Model:  gorm.Model{ID: 7, CreatedAt: time.Date(2025, 3, 1, 12, 30, 0, 0, time.UTC)},
+		UserID: 3,
+		Status: "completed",
+	}
+	for i := 0; i < items; i++ {
+		order.OrderItems = append(order.OrderItems, models.OrderItem{
+			MenuItemID: uint(i + 1),
+			Quantity:   2,
+			Price:      1.25,
+		})
+	}
+	return order
+}
+
+func TestBuild(t *testing.T) {
+	r := Build(testOrder(2), map[uint]string{1: "Flat White"})
+
+	require.Len(t, r.Lines, 2)
+	assert.Equal(t, "Flat White", r.Lines[0].Name)
+	assert.Equal(t, "Menu item #2", r.Lines[1].Name)
+	assert.InDelta(t, 2.50, r.Lines[0].LineTotal, 0.001)
+	assert.InDelta(t, 5.00, r.Total, 0.001)
+}
+
+func TestBuildPrefersSnapshottedName(t *testing.T) {
+	order := testOrder(1)
+	order.OrderItems[0].Name = "Cappuccino"
+
+	r := Build(order, map[uint]string{1: "Renamed Cappuccino"})
+
+	assert.Equal(t, "Cappuccino", r.Lines[0].Name)
+}
+
+func TestBuildIncludesModifierDeltas(t *testing.T) {
+	order := testOrder(1)
+	order.OrderItems[0].Modifiers = []models.OrderItemModifier{
+		{Name: "Large", PriceDelta: 0.75},
+		{Name: "Oat milk", PriceDelta: 0.50},
+	}
+
+	r := Build(order, nil)
+
+	assert.InDelta(t, 2.50, r.Lines[0].UnitPrice, 0.001)
+	assert.InDelta(t, 5.00, r.Lines[0].LineTotal, 0.001)
+	assert.InDelta(t, 5.00, r.Total, 0.001)
+	assert.Contains(t, string(r.Text()), "+ Oat milk")
+}
+
+func TestBuildListsBundleComponents(t *testing.T) {
+	order := testOrder(1)
+	order.OrderItems[0].Name = "Lunch Deal"
+	order.OrderItems[0].Price = 8.00
+	order.OrderItems[0].Quantity = 1
+	order.OrderItems[0].Components = []models.OrderItemComponent{
+		{SlotName: "Sandwich", Name: "Club Sandwich", Upcharge: 1.00},
+		{SlotName: "Drink", Name: "Latte", Modifiers: []models.OrderItemModifier{{Name: "Oat milk", PriceDelta: 0.50}}},
+	}
+
+	r := Build(order, nil)
+
+	require.Len(t, r.Lines[0].Components, 2)
+	assert.InDelta(t, 9.50, r.Lines[0].UnitPrice, 0.001)
+	assert.InDelta(t, 9.50, r.Total, 0.001)
+	text := string(r.Text())
+	assert.Contains(t, text, "* Club Sandwich")
+	assert.Contains(t, text, "* Latte")
+	assert.Contains(t, text, "+ Oat milk")
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		assert.LessOrEqual(t, len([]rune(line)), lineWidth, "line too wide: %q", line)
+	}
+}
+
+func TestText(t *testing.T) {
+	text := string(Build(testOrder(1), map[uint]string{1: "A really long sandwich name with extras"}).Text())
+
+	assert.Contains(t, text, "Receipt for order #7")
+	assert.Contains(t, text, "2025-03-01 12:30")
+	assert.Contains(t, text, "A really long sandwi~")
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		assert.LessOrEqual(t, len([]rune(line)), lineWidth, "line too wide: %q", line)
+	}
+}
+
+func TestHTMLEscapesNames(t *testing.T) {
+	html, err := Build(testOrder(1), map[uint]string{1: "<b>Tea</b>"}).HTML()
+
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "&lt;b&gt;Tea&lt;/b&gt;")
+	assert.NotContains(t, string(html), "<b>Tea</b>")
+}
+
+func TestPDF(t *testing.T) {
+	t.Run("escapes special characters", func(t *testing.T) {
+		pdf := Build(testOrder(1), map[uint]string{1: `Tea (large) \ café`}).PDF()
+		assert.Contains(t, string(pdf), `Tea \(large\) \\ caf\351`)
+	})
+
+	t.Run("uses WinAnsi bytes 128-159", func(t *testing.T) {
+		pdf := Build(testOrder(1), map[uint]string{1: "Tea – “hot” €\u0085"}).PDF()
+		assert.Contains(t, string(pdf), `Tea \226 \223hot\224 \200?`)
+	})
+
+	t.Run("xref offsets point at objects", func(t *testing.T) {
+		pdf := Build(testOrder(1), nil).PDF()
+
+		start := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+		require.NotNil(t, start)
+		xref, err := strconv.Atoi(string(start[1]))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(pdf[xref:], []byte("xref\n")))
+
+		offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+		require.NotEmpty(t, offsets)
+		for i, m := range offsets {
+			off, _ := strconv.Atoi(string(m[1]))
+			assert.True(t, bytes.HasPrefix(pdf[off:], []byte(fmt.Sprintf("%d 0 obj", i+1))))
+		}
+	})
+
+	t.Run("long receipts span pages", func(t *testing.T) {
+		pdf := Build(testOrder(linesPerPage), nil).PDF()
+		assert.Contains(t, string(pdf), "/Count 2")
+	})
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/douglasswm/student-cafe-common/logging"
+)
+
+// DefaultWatchInterval is how often Watch checks the config file for changes
+const DefaultWatchInterval = 5 * time.Second
+
+// Reloader re-reads the configuration on SIGHUP or when the YAML file
+// changes and applies the fields tagged reload. Other changed fields are
+// logged and ignored until restart.
+type Reloader struct {
+	opts    Options
+	loadMod time.Time
+
+	mu        sync.RWMutex
+	current   Config
+	listeners []func(Config)
+}
+
+// NewReloader starts from cfg, as returned by Load(opts)
+func NewReloader(opts Options, cfg Config) *Reloader {
+	r := &Reloader{opts: opts, current: cfg}
+	r.loadMod = r.fileModTime()
+	return r
+}
+
+// Current returns the configuration in effect
+func (r *Reloader) Current() Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// OnChange registers fn to be called with the new configuration after a
+// reload changed any reloadable field
+func (r *Reloader) OnChange(fn func(Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, fn)
+}
+
+// Reload loads the configuration again and applies the reloadable fields.
+// An invalid configuration is rejected and the current one kept.
+func (r *Reloader) Reload() error {
+	next, err := Load(r.opts)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	updated := r.current
+	changed := false
+	eachField(func(f reflect.StructField) {
+		old := reflect.ValueOf(r.current).FieldByIndex(f.Index)
+		value := reflect.ValueOf(next).FieldByIndex(f.Index)
+		if reflect.DeepEqual(old.Interface(), value.Interface()) {
+			return
+		}
+		key := f.Tag.Get("yaml")
+		if f.Tag.Get("reload") != "true" {
+			slog.Warn("Config change requires a restart, ignoring", "field", key)
+			return
+		}
+		slog.Info("Config reloaded", "field", key, "value", display(f, value))
+		reflect.ValueOf(&updated).Elem().FieldByIndex(f.Index).Set(value)
+		changed = true
+	})
+	r.current = updated
+	listeners := append([]func(Config){}, r.listeners...)
+	r.mu.Unlock()
+
+	if changed {
+		for _, fn := range listeners {
+			fn(updated)
+		}
+	}
+	return nil
+}
+
+// Watch reloads on SIGHUP and whenever the config file's modification time
+// changes (checked every interval) until ctx is done
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastMod := r.loadMod
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		case <-ticker.C:
+			mod := r.fileModTime()
+			if mod.Equal(lastMod) {
+				continue
+			}
+			lastMod = mod
+		}
+		if err := r.Reload(); err != nil {
+			slog.Error("Config reload failed, keeping current config", "error", err)
+		}
+	}
+}
+
+// ApplyLogLevel is an OnChange listener applying the reloaded log level to
+// the logger installed by logging.Setup
+func ApplyLogLevel(cfg Config) {
+	if level, err := logging.ParseLevel(cfg.LogLevel); err == nil {
+		logging.SetLevel(level)
+	}
+}
+
+// fileModTime returns the config file's modification time, or the zero
+// time when there is no file
+func (r *Reloader) fileModTime() time.Time {
+	path := filePath(r.opts)
+	if path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
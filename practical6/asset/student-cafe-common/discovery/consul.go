@@ -0,0 +1,83 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+// Consul registers instances with a Consul agent. Each instance gets a TTL
+// check that SetHealth passes or fails, so Consul lists only instances that
+// are reporting healthy, and drops ones that stop reporting altogether
+type Consul struct {
+	client *consulapi.Client
+}
+
+// NewConsul returns a registry using the Consul agent at addr
+func NewConsul(addr string) (*Consul, error) {
+	cfg := consulapi.DefaultConfig()
+	cfg.Address = addr
+	client, err := consulapi.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Consul{client: client}, nil
+}
+
+func (c *Consul) Instances(ctx context.Context, service string) ([]Instance, error) {
+	entries, _, err := c.client.Health().Service(service, "", true, (&consulapi.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Instance, 0, len(entries))
+	for _, e := range entries {
+		host := e.Service.Address
+		if host == "" {
+			host = e.Node.Address
+		}
+		out = append(out, Instance{ID: e.Service.ID, Service: service, Address: net.JoinHostPort(host, strconv.Itoa(e.Service.Port))})
+	}
+	return out, nil
+}
+
+func (c *Consul) Register(ctx context.Context, inst Instance) error {
+	host, portStr, err := net.SplitHostPort(inst.Address)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return fmt.Errorf("invalid port in %q: %w", inst.Address, err)
+	}
+	return c.client.Agent().ServiceRegisterOpts(&consulapi.AgentServiceRegistration{
+		ID:      inst.ID,
+		Name:    inst.Service,
+		Address: host,
+		Port:    port,
+		Check: &consulapi.AgentServiceCheck{
+			CheckID:                        checkID(inst),
+			TTL:                            TTL.String(),
+			Status:                         consulapi.HealthCritical,
+			DeregisterCriticalServiceAfter: (4 * TTL).String(),
+		},
+	}, consulapi.ServiceRegisterOpts{}.WithContext(ctx))
+}
+
+func (c *Consul) SetHealth(ctx context.Context, inst Instance, healthy bool) error {
+	status, output := consulapi.HealthPassing, "SERVING"
+	if !healthy {
+		status, output = consulapi.HealthCritical, "NOT_SERVING"
+	}
+	return c.client.Agent().UpdateTTLOpts(checkID(inst), output, status, (&consulapi.QueryOptions{}).WithContext(ctx))
+}
+
+func (c *Consul) Deregister(ctx context.Context, inst Instance) error {
+	return c.client.Agent().ServiceDeregisterOpts(inst.ID, (&consulapi.QueryOptions{}).WithContext(ctx))
+}
+
+func checkID(inst Instance) string {
+	return "service:" + inst.ID
+}
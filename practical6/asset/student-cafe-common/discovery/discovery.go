@@ -0,0 +1,198 @@
+// Package discovery finds the running instances of the cafe services. A
+// Registry is backed by a static table, a Consul agent or a shared
+// directory; services register themselves in it at startup and report
+// their health while they run, and clients dial "discovery:///menu-service"
+// to be balanced across the healthy instances.
+package discovery
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// Registry types
+const (
+	// TypeStatic reads a fixed table of addresses, e.g.
+	// "menu-service=menu-1:9092,menu-2:9092;user-service=user:9091"
+	TypeStatic = "static"
+	// TypeConsul uses the Consul agent at the configured address
+	TypeConsul = "consul"
+	// TypeFile shares a directory, with one file per instance
+	TypeFile = "file"
+)
+
+// TTL is how long an instance stays listed after it last reported its
+// health. Register reports three times per TTL
+var TTL = 15 * time.Second
+
+// Instance is one running copy of a service
+type Instance struct {
+	// ID is unique across every instance of every service
+	ID      string `json:"id"`
+	Service string `json:"service"`
+	// Address is the host:port clients dial
+	Address string `json:"address"`
+}
+
+// Registry tracks which instances of each service are running and healthy
+type Registry interface {
+	// Instances returns the healthy instances of service
+	Instances(ctx context.Context, service string) ([]Instance, error)
+	// Register adds inst, unhealthy until SetHealth reports otherwise
+	Register(ctx context.Context, inst Instance) error
+	// SetHealth reports whether inst should receive calls. An instance that
+	// hasn't reported for TTL is treated as unhealthy
+	SetHealth(ctx context.Context, inst Instance, healthy bool) error
+	// Deregister removes inst
+	Deregister(ctx context.Context, inst Instance) error
+}
+
+// Config selects and configures a Registry. The zero Config disables
+// discovery
+type Config struct {
+	// Type is TypeStatic, TypeConsul or TypeFile
+	Type string
+	// Addr is the static table, the Consul agent's host:port or the
+	// registry directory
+	Addr string
+}
+
+// Enabled reports whether a registry is configured
+func (c Config) Enabled() bool {
+	return c.Type != ""
+}
+
+// Validate checks the type is known and has an address
+func (c Config) Validate() error {
+	switch c.Type {
+	case "":
+		return nil
+	case TypeStatic:
+		_, err := parseTable(c.Addr)
+		return err
+	case TypeConsul, TypeFile:
+		if c.Addr == "" {
+			return fmt.Errorf("%s discovery needs an address", c.Type)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown discovery type %q: want %s, %s or %s", c.Type, TypeStatic, TypeConsul, TypeFile)
+	}
+}
+
+// New returns the registry cfg describes, or nil when discovery is disabled
+func New(cfg Config) (Registry, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Type {
+	case TypeStatic:
+		return NewStatic(cfg.Addr)
+	case TypeConsul:
+		return NewConsul(cfg.Addr)
+	case TypeFile:
+		return NewFile(cfg.Addr), nil
+	}
+	return nil, nil
+}
+
+// NewInstance describes this process as an instance of service reachable
+// at addr. When addr is only a port (":9092") the host name is used. The
+// ID comes from the host name and a random suffix rather than addr, as
+// replicas behind one name all advertise the same address
+func NewInstance(service, addr string) (Instance, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return Instance{}, fmt.Errorf("invalid address %q for %s: %w", addr, service, err)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return Instance{}, err
+	}
+	if host == "" {
+		host = hostname
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return Instance{}, err
+	}
+	return Instance{
+		ID:      fmt.Sprintf("%s-%s-%x", service, hostname, suffix),
+		Service: service,
+		Address: net.JoinHostPort(host, port),
+	}, nil
+}
+
+// Registration keeps one instance registered with its health up to date
+type Registration struct {
+	registry Registry
+	inst     Instance
+	healthy  func() bool
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Register adds inst to r and reports healthy() to it every TTL/3 until
+// Deregister is called
+func Register(ctx context.Context, r Registry, inst Instance, healthy func() bool) (*Registration, error) {
+	if err := r.Register(ctx, inst); err != nil {
+		return nil, fmt.Errorf("failed to register %s: %w", inst.ID, err)
+	}
+	reg := &Registration{registry: r, inst: inst, healthy: healthy, stop: make(chan struct{}), done: make(chan struct{})}
+	reg.report()
+	go reg.run()
+	slog.Info("Registered for discovery", "id", inst.ID, "address", inst.Address)
+	return reg, nil
+}
+
+func (reg *Registration) run() {
+	defer close(reg.done)
+	ticker := time.NewTicker(TTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-reg.stop:
+			return
+		case <-ticker.C:
+			reg.report()
+		}
+	}
+}
+
+// report passes the current health to the registry
+func (reg *Registration) report() {
+	ctx, cancel := context.WithTimeout(context.Background(), TTL/3)
+	defer cancel()
+	if err := reg.registry.SetHealth(ctx, reg.inst, reg.healthy()); err != nil {
+		slog.Warn("Failed to report health for discovery", "id", reg.inst.ID, "error", err)
+	}
+}
+
+// Deregister stops reporting and removes the instance, so clients stop
+// resolving it before the server drains
+func (reg *Registration) Deregister(ctx context.Context) error {
+	close(reg.stop)
+	<-reg.done
+	if err := reg.registry.Deregister(ctx, reg.inst); err != nil {
+		return fmt.Errorf("failed to deregister %s: %w", reg.inst.ID, err)
+	}
+	slog.Info("Deregistered from discovery", "id", reg.inst.ID)
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
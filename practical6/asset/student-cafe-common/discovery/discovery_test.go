@@ -0,0 +1,323 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/serviceconfig"
+)
+
+func addresses(t *testing.T, r Registry, service string) []string {
+	t.Helper()
+	instances, err := r.Instances(context.Background(), service)
+	require.NoError(t, err)
+	var out []string
+	for _, inst := range instances {
+		out = append(out, inst.Address)
+	}
+	return out
+}
+
+func TestStatic(t *testing.T) {
+	s, err := NewStatic("menu-service=menu-1:9092, menu-2:9092; user-service=user:9091;")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"menu-1:9092", "menu-2:9092"}, addresses(t, s, "menu-service"))
+	assert.Empty(t, addresses(t, s, "order-service"))
+
+	for _, table := range []string{"menu-service", "=menu:9092", "menu-service=menu"} {
+		_, err := NewStatic(table)
+		assert.Error(t, err, table)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.False(t, Config{}.Enabled())
+	assert.NoError(t, Config{Type: TypeFile, Addr: "/registry"}.Validate())
+	assert.Error(t, Config{Type: TypeConsul}.Validate())
+	assert.Error(t, Config{Type: TypeStatic, Addr: "menu-service=menu"}.Validate())
+	assert.Error(t, Config{Type: "etcd", Addr: "etcd:2379"}.Validate())
+
+	r, err := New(Config{})
+	require.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestNewInstance(t *testing.T) {
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	inst, err := NewInstance("menu-service", ":9092")
+	require.NoError(t, err)
+	assert.Equal(t, host+":9092", inst.Address)
+	assert.Regexp(t, "^menu-service-"+regexp.QuoteMeta(host)+"-[0-9a-f]{8}$", inst.ID)
+
+	inst, err = NewInstance("menu-service", "10.0.0.5:9092")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5:9092", inst.Address)
+
+	_, err = NewInstance("menu-service", "9092")
+	assert.Error(t, err)
+}
+
+func TestNewInstance_ReplicasSharingAnAddress(t *testing.T) {
+	// Replicas scaled behind one service name all advertise that name
+	ctx := context.Background()
+	f := NewFile(t.TempDir())
+	var ids []string
+	for i := 0; i < 2; i++ {
+		inst, err := NewInstance("menu-service", "menu-service:9092")
+		require.NoError(t, err)
+		require.NoError(t, f.Register(ctx, inst))
+		require.NoError(t, f.SetHealth(ctx, inst, true))
+		ids = append(ids, inst.ID)
+	}
+	assert.NotEqual(t, ids[0], ids[1])
+
+	instances, err := f.Instances(ctx, "menu-service")
+	require.NoError(t, err)
+	assert.Len(t, instances, 2, "each replica keeps its own registration")
+}
+
+func TestFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	f := NewFile(dir)
+	a := Instance{ID: "menu-a", Service: "menu-service", Address: "menu-a:9092"}
+	b := Instance{ID: "menu-b", Service: "menu-service", Address: "menu-b:9092"}
+
+	assert.Empty(t, addresses(t, f, "menu-service"), "no directory yet")
+	require.NoError(t, f.Register(ctx, a))
+	require.NoError(t, f.Register(ctx, b))
+	assert.Empty(t, addresses(t, f, "menu-service"), "unhealthy until reported")
+
+	require.NoError(t, f.SetHealth(ctx, a, true))
+	require.NoError(t, f.SetHealth(ctx, b, true))
+	assert.ElementsMatch(t, []string{"menu-a:9092", "menu-b:9092"}, addresses(t, f, "menu-service"))
+
+	// b stops reporting
+	old := time.Now().Add(-2 * TTL)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "menu-service", "menu-b.json"), old, old))
+	assert.Equal(t, []string{"menu-a:9092"}, addresses(t, f, "menu-service"))
+
+	require.NoError(t, f.SetHealth(ctx, a, false))
+	assert.Empty(t, addresses(t, f, "menu-service"))
+
+	require.NoError(t, f.Deregister(ctx, a))
+	require.NoError(t, f.Deregister(ctx, a), "deregistering twice is fine")
+	_, err := os.Stat(filepath.Join(dir, "menu-service", "menu-a.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRegisterReportsHealth(t *testing.T) {
+	defer func(ttl time.Duration) { TTL = ttl }(TTL)
+	TTL = 30 * time.Millisecond
+
+	f := NewFile(t.TempDir())
+	var healthy atomic.Bool
+	reg, err := Register(context.Background(), f, Instance{ID: "menu-a", Service: "menu-service", Address: "menu-a:9092"}, healthy.Load)
+	require.NoError(t, err)
+	assert.Empty(t, addresses(t, f, "menu-service"))
+
+	healthy.Store(true)
+	require.Eventually(t, func() bool { return len(addresses(t, f, "menu-service")) == 1 }, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, reg.Deregister(context.Background()))
+	assert.Empty(t, addresses(t, f, "menu-service"))
+}
+
+// fakeConsul serves the parts of the Consul agent and health APIs the
+// registry uses
+func fakeConsul(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	services := map[string]consulapi.AgentServiceRegistration{}
+	status := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/v1/agent/service/register":
+			var reg consulapi.AgentServiceRegistration
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&reg))
+			services[reg.ID] = reg
+			status[reg.Check.CheckID] = reg.Check.Status
+		case strings.HasPrefix(r.URL.Path, "/v1/agent/check/update/"):
+			var update struct{ Status string }
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			status[strings.TrimPrefix(r.URL.Path, "/v1/agent/check/update/")] = update.Status
+		case strings.HasPrefix(r.URL.Path, "/v1/agent/service/deregister/"):
+			delete(services, strings.TrimPrefix(r.URL.Path, "/v1/agent/service/deregister/"))
+		case strings.HasPrefix(r.URL.Path, "/v1/health/service/"):
+			name := strings.TrimPrefix(r.URL.Path, "/v1/health/service/")
+			assert.Equal(t, "1", r.URL.Query().Get("passing"))
+			entries := []*consulapi.ServiceEntry{}
+			for _, reg := range services {
+				if reg.Name == name && status[reg.Check.CheckID] == consulapi.HealthPassing {
+					entries = append(entries, &consulapi.ServiceEntry{
+						Node:    &consulapi.Node{Address: "10.0.0.1"},
+						Service: &consulapi.AgentService{ID: reg.ID, Service: reg.Name, Address: reg.Address, Port: reg.Port},
+					})
+				}
+			}
+			json.NewEncoder(w).Encode(entries)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestConsul(t *testing.T) {
+	ctx := context.Background()
+	srv := fakeConsul(t)
+	c, err := NewConsul(strings.TrimPrefix(srv.URL, "http://"))
+	require.NoError(t, err)
+
+	a := Instance{ID: "menu-a", Service: "menu-service", Address: "menu-a:9092"}
+	require.NoError(t, c.Register(ctx, a))
+	assert.Empty(t, addresses(t, c, "menu-service"), "critical until reported")
+
+	require.NoError(t, c.SetHealth(ctx, a, true))
+	assert.Equal(t, []string{"menu-a:9092"}, addresses(t, c, "menu-service"))
+
+	require.NoError(t, c.SetHealth(ctx, a, false))
+	assert.Empty(t, addresses(t, c, "menu-service"))
+
+	require.NoError(t, c.SetHealth(ctx, a, true))
+	require.NoError(t, c.Deregister(ctx, a))
+	assert.Empty(t, addresses(t, c, "menu-service"))
+
+	assert.Error(t, c.Register(ctx, Instance{ID: "bad", Service: "menu-service", Address: "menu-a"}))
+}
+
+// mutableRegistry is a registry whose instances a test changes directly
+type mutableRegistry struct {
+	Static
+	mu        sync.Mutex
+	instances []Instance
+	err       error
+}
+
+// fail makes lookups return err until called again with nil
+func (r *mutableRegistry) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
+
+func (r *mutableRegistry) set(addrs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.instances = nil
+	for _, addr := range addrs {
+		r.instances = append(r.instances, Instance{ID: addr, Service: "menu-service", Address: addr})
+	}
+}
+
+func (r *mutableRegistry) Instances(ctx context.Context, service string) ([]Instance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.instances, r.err
+}
+
+// fakeClientConn records what a resolver passes to the connection
+type fakeClientConn struct {
+	resolver.ClientConn
+	mu     sync.Mutex
+	states []resolver.State
+	errs   []error
+}
+
+func (cc *fakeClientConn) UpdateState(s resolver.State) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.states = append(cc.states, s)
+	return nil
+}
+
+func (cc *fakeClientConn) ReportError(err error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.errs = append(cc.errs, err)
+}
+
+func (cc *fakeClientConn) ParseServiceConfig(string) *serviceconfig.ParseResult { return nil }
+
+func (cc *fakeClientConn) counts() (states, errs int) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return len(cc.states), len(cc.errs)
+}
+
+func (cc *fakeClientConn) last() resolver.State {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.states[len(cc.states)-1]
+}
+
+func TestResolver(t *testing.T) {
+	defer func(d time.Duration) { RefreshInterval = d }(RefreshInterval)
+	RefreshInterval = 10 * time.Millisecond
+
+	reg := &mutableRegistry{}
+	reg.set("menu-b:9092", "menu-a:9092")
+	cc := &fakeClientConn{}
+	builder := NewResolver(reg)
+	assert.Equal(t, "discovery", builder.Scheme())
+
+	r, err := builder.Build(resolver.Target{URL: *mustParse(t, "discovery:///menu-service")}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.Eventually(t, func() bool { n, _ := cc.counts(); return n == 1 }, time.Second, 5*time.Millisecond)
+	state := cc.last()
+	require.Len(t, state.Addresses, 2)
+	assert.Equal(t, "menu-a:9092", state.Addresses[0].Addr)
+	assert.Equal(t, "menu-service", state.Addresses[0].ServerName, "verified under the service name")
+
+	// Unchanged lookups aren't passed on; an empty one drops every address
+	time.Sleep(5 * RefreshInterval)
+	n, _ := cc.counts()
+	assert.Equal(t, 1, n)
+	reg.set()
+	require.Eventually(t, func() bool { n, _ := cc.counts(); return n == 2 }, time.Second, 5*time.Millisecond)
+	assert.Empty(t, cc.last().Addresses)
+
+	reg.set("menu-a:9092", "menu-c:9092")
+	require.Eventually(t, func() bool { n, _ := cc.counts(); return n == 3 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "menu-c:9092", cc.last().Addresses[1].Addr)
+
+	// Only a failed lookup is an error, after which the instances are
+	// passed on again
+	reg.fail(errors.New("registry unreachable"))
+	require.Eventually(t, func() bool { _, errs := cc.counts(); return errs > 0 }, time.Second, 5*time.Millisecond)
+	reg.fail(nil)
+	require.Eventually(t, func() bool { n, _ := cc.counts(); return n == 4 }, time.Second, 5*time.Millisecond)
+	assert.Len(t, cc.last().Addresses, 2)
+
+	_, err = builder.Build(resolver.Target{URL: *mustParse(t, "discovery:///")}, cc, resolver.BuildOptions{})
+	assert.Error(t, err)
+}
+
+func mustParse(t *testing.T, target string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(target)
+	require.NoError(t, err)
+	return u
+}
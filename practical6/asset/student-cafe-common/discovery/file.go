@@ -0,0 +1,110 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// File keeps the registry in a directory shared by every instance, such as
+// a volume mounted into each container. Each instance owns one file,
+// <dir>/<service>/<id>.json, which it rewrites whenever it reports its
+// health; files not rewritten within TTL belong to instances that stopped
+// without deregistering and are ignored
+type File struct {
+	dir string
+}
+
+// fileEntry is the content of an instance's file
+type fileEntry struct {
+	Instance
+	Healthy bool `json:"healthy"`
+}
+
+// NewFile returns a registry kept in dir
+func NewFile(dir string) *File {
+	return &File{dir: dir}
+}
+
+func (f *File) Instances(ctx context.Context, service string) ([]Instance, error) {
+	files, err := os.ReadDir(filepath.Join(f.dir, service))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Instance
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil || time.Since(info.ModTime()) > TTL {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(f.dir, service, file.Name()))
+		if err != nil {
+			continue // deregistered since the directory was read
+		}
+		var entry fileEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("invalid registry file %s: %w", file.Name(), err)
+		}
+		if entry.Healthy {
+			out = append(out, entry.Instance)
+		}
+	}
+	return out, nil
+}
+
+func (f *File) Register(ctx context.Context, inst Instance) error {
+	return f.write(inst, false)
+}
+
+func (f *File) SetHealth(ctx context.Context, inst Instance, healthy bool) error {
+	return f.write(inst, healthy)
+}
+
+func (f *File) Deregister(ctx context.Context, inst Instance) error {
+	err := os.Remove(f.path(inst))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (f *File) path(inst Instance) string {
+	return filepath.Join(f.dir, inst.Service, inst.ID+".json")
+}
+
+// write replaces inst's file atomically, so readers never see it half written
+func (f *File) write(inst Instance, healthy bool) error {
+	data, err := json.Marshal(fileEntry{Instance: inst, Healthy: healthy})
+	if err != nil {
+		return err
+	}
+	path := f.path(inst)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,147 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/resolver"
+)
+
+// Scheme is the target scheme resolved through a Registry, as in
+// "discovery:///menu-service"
+const Scheme = "discovery"
+
+// RefreshInterval is how often a resolver looks the service up again
+var RefreshInterval = 5 * time.Second
+
+// DialOption lets a connection dial discovery:/// targets through r. It
+// changes nothing when r is nil
+func DialOption(r Registry) grpc.DialOption {
+	if r == nil {
+		return grpc.EmptyDialOption{}
+	}
+	return grpc.WithResolvers(NewResolver(r))
+}
+
+// NewResolver returns a gRPC resolver for discovery:/// targets that lists
+// the healthy instances of the service named in the target's path
+func NewResolver(r Registry) resolver.Builder {
+	return resolverBuilder{registry: r}
+}
+
+type resolverBuilder struct {
+	registry Registry
+}
+
+func (b resolverBuilder) Scheme() string { return Scheme }
+
+func (b resolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	service := strings.TrimPrefix(target.Endpoint(), "/")
+	if service == "" || strings.Contains(service, "/") {
+		return nil, fmt.Errorf("invalid discovery target %q: want %s:///<service>", target.URL.String(), Scheme)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &serviceResolver{
+		registry: b.registry,
+		service:  service,
+		cc:       cc,
+		cancel:   cancel,
+		now:      make(chan struct{}, 1),
+	}
+	r.wg.Add(1)
+	go r.watch(ctx)
+	return r, nil
+}
+
+// serviceResolver polls the registry for one service and passes changes
+// in its instances on to the connection
+type serviceResolver struct {
+	registry Registry
+	service  string
+	cc       resolver.ClientConn
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	// now asks for an immediate lookup
+	now chan struct{}
+}
+
+func (r *serviceResolver) watch(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(RefreshInterval)
+	defer ticker.Stop()
+	// last is the set of addresses the connection has, once known
+	var last []string
+	known := false
+	for {
+		if addrs, err := r.lookup(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("Service discovery failed", "service", r.service, "error", err)
+			r.cc.ReportError(err)
+			known = false // pass the instances on again once the registry is back
+		} else if !known || !slices.Equal(addrs, last) {
+			last, known = addrs, true
+			r.update(addrs)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.now:
+		}
+	}
+}
+
+// lookup returns the sorted addresses of the healthy instances
+func (r *serviceResolver) lookup(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, RefreshInterval)
+	defer cancel()
+	instances, err := r.registry.Instances(ctx, r.service)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		addrs = append(addrs, inst.Address)
+	}
+	slices.Sort(addrs)
+	return slices.Compact(addrs), nil
+}
+
+// update passes addrs to the connection. Instances are verified under the
+// service's name, whatever address they registered with. An empty list
+// drops the old instances, so calls fail fast with UNAVAILABLE instead of
+// going to instances that have left or turned unhealthy
+func (r *serviceResolver) update(addrs []string) {
+	state := resolver.State{}
+	for _, addr := range addrs {
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: addr, ServerName: r.service})
+	}
+	if len(addrs) == 0 {
+		slog.Warn("No healthy instances found", "service", r.service)
+	}
+	// The balancer rejects an empty list, which is expected here
+	if err := r.cc.UpdateState(state); err != nil && len(addrs) > 0 {
+		slog.Warn("Discovered instances rejected", "service", r.service, "error", err)
+	}
+}
+
+func (r *serviceResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.now <- struct{}{}:
+	default:
+	}
+}
+
+func (r *serviceResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
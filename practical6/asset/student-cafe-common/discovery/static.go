@@ -0,0 +1,53 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Static is a fixed table of service addresses. Every listed instance is
+// assumed healthy, and registering changes nothing
+type Static struct {
+	table map[string][]Instance
+}
+
+// NewStatic parses a table such as
+// "menu-service=menu-1:9092,menu-2:9092;user-service=user:9091"
+func NewStatic(table string) (*Static, error) {
+	t, err := parseTable(table)
+	if err != nil {
+		return nil, err
+	}
+	return &Static{table: t}, nil
+}
+
+func parseTable(table string) (map[string][]Instance, error) {
+	t := map[string][]Instance{}
+	for _, entry := range strings.Split(table, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		service, addrs, ok := strings.Cut(entry, "=")
+		service = strings.TrimSpace(service)
+		if !ok || service == "" {
+			return nil, fmt.Errorf("invalid static discovery entry %q: want service=host:port,...", entry)
+		}
+		for _, addr := range splitList(addrs) {
+			if _, _, err := net.SplitHostPort(addr); err != nil {
+				return nil, fmt.Errorf("invalid address %q for %s: %w", addr, service, err)
+			}
+			t[service] = append(t[service], Instance{ID: service + "-" + addr, Service: service, Address: addr})
+		}
+	}
+	return t, nil
+}
+
+func (s *Static) Instances(ctx context.Context, service string) ([]Instance, error) {
+	return s.table[service], nil
+}
+
+func (s *Static) Register(context.Context, Instance) error        { return nil }
+func (s *Static) SetHealth(context.Context, Instance, bool) error { return nil }
+func (s *Static) Deregister(context.Context, Instance) error      { return nil }
@@ -0,0 +1,194 @@
+package grpcclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BreakerState is the state of a circuit breaker
+type BreakerState int
+
+const (
+	// StateClosed lets every call through and counts failures
+	StateClosed BreakerState = iota
+	// StateOpen rejects every call until the open timeout has passed
+	StateOpen
+	// StateHalfOpen lets a single probe call through to test the dependency
+	StateHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerSettings configures a circuit breaker
+type BreakerSettings struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before allowing a probe
+	OpenTimeout time.Duration
+}
+
+// Breaker is a consecutive-failure circuit breaker for one dependency
+type Breaker struct {
+	name     string
+	settings BreakerSettings
+	now      func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker creates a closed breaker for the named dependency
+func NewBreaker(name string, settings BreakerSettings) *Breaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = DefaultBreakerSettings.FailureThreshold
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = DefaultBreakerSettings.OpenTimeout
+	}
+	return &Breaker{name: name, settings: settings, now: time.Now}
+}
+
+// State returns the current state of the breaker
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.currentState()
+}
+
+// currentState moves an open breaker to half-open once the open timeout has
+// passed. Callers must hold b.mu.
+func (b *Breaker) currentState() BreakerState {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.state = StateHalfOpen
+		b.probing = false
+	}
+	return b.state
+}
+
+// allow reports whether a call may go through
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case StateOpen:
+		return false
+	case StateHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// outcome is what a call shows about the dependency's health
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	// outcomeNeutral is a call that shows nothing either way, such as one
+	// the caller cancelled or the server rejected as invalid
+	outcomeNeutral
+)
+
+// record updates the breaker with the outcome of a call. A neutral outcome
+// only frees the half-open probe slot for the next call
+func (b *Breaker) record(o outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch o {
+	case outcomeNeutral:
+		b.probing = false
+		return
+	case outcomeSuccess:
+		b.state = StateClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.settings.FailureThreshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+		b.probing = false
+	}
+}
+
+// UnaryClientInterceptor fails calls fast with Unavailable while the breaker is open
+func (b *Breaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if bypassesBreaker(opts) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if !b.allow() {
+			return status.Errorf(codes.Unavailable, "%s is unavailable: circuit breaker open", b.name)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(callOutcome(ctx, err))
+		return err
+	}
+}
+
+// skipBreaker is a call option that lets a call past the breaker without
+// being counted by it
+type skipBreaker struct{ grpc.EmptyCallOption }
+
+func bypassesBreaker(opts []grpc.CallOption) bool {
+	for _, opt := range opts {
+		if _, ok := opt.(skipBreaker); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// callOutcome classifies a finished call. Errors caused by the caller, such
+// as a cancelled context or an invalid request, are neutral: they neither
+// count against the breaker nor show the dependency has recovered.
+func callOutcome(ctx context.Context, err error) outcome {
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case ctx.Err() == context.Canceled, !isFailureCode(status.Code(err)):
+		return outcomeNeutral
+	default:
+		return outcomeFailure
+	}
+}
+
+// isFailureCode reports whether a call that ended with code suggests the
+// server, rather than the request, is at fault
+func isFailureCode(code codes.Code) bool {
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,84 @@
+package grpcclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreaker_StateTransitions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := NewBreaker("menu-service", BreakerSettings{FailureThreshold: 2, OpenTimeout: time.Minute})
+	b.now = func() time.Time { return now }
+
+	// A success resets the consecutive failure count
+	assert.True(t, b.allow())
+	b.record(outcomeFailure)
+	b.record(outcomeSuccess)
+	b.record(outcomeFailure)
+	assert.Equal(t, StateClosed, b.State())
+
+	b.record(outcomeFailure)
+	assert.Equal(t, StateOpen, b.State())
+	assert.False(t, b.allow())
+
+	// After the open timeout a single probe is allowed
+	now = now.Add(time.Minute)
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.True(t, b.allow())
+	assert.False(t, b.allow(), "only one probe at a time")
+
+	// A failed probe reopens the breaker
+	b.record(outcomeFailure)
+	assert.Equal(t, StateOpen, b.State())
+
+	// A successful probe closes it
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow())
+	b.record(outcomeSuccess)
+	assert.Equal(t, StateClosed, b.State())
+	assert.True(t, b.allow())
+}
+
+func TestBreaker_NeutralOutcomes(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := NewBreaker("menu-service", BreakerSettings{FailureThreshold: 2, OpenTimeout: time.Minute})
+	b.now = func() time.Time { return now }
+
+	// A caller error does not reset the consecutive failure count
+	b.record(outcomeFailure)
+	b.record(outcomeNeutral)
+	b.record(outcomeFailure)
+	assert.Equal(t, StateOpen, b.State())
+
+	// A neutral probe leaves the breaker half-open and frees the slot
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow())
+	b.record(outcomeNeutral)
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.True(t, b.allow(), "the next call may probe")
+	b.record(outcomeSuccess)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestCallOutcome(t *testing.T) {
+	ctx := context.Background()
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	assert.Equal(t, outcomeSuccess, callOutcome(ctx, nil))
+	assert.Equal(t, outcomeFailure, callOutcome(ctx, status.Error(codes.Unavailable, "down")))
+	assert.Equal(t, outcomeNeutral, callOutcome(ctx, status.Error(codes.InvalidArgument, "bad id")))
+	assert.Equal(t, outcomeNeutral, callOutcome(cancelled, status.Error(codes.Canceled, "context canceled")))
+}
+
+func TestNewBreaker_Defaults(t *testing.T) {
+	b := NewBreaker("user-service", BreakerSettings{})
+
+	assert.Equal(t, DefaultBreakerSettings, b.settings)
+	assert.Equal(t, "closed", b.State().String())
+}
@@ -0,0 +1,300 @@
+// Package grpcclient builds gRPC client connections between the cafe
+// services with per-method deadlines, retries for idempotent reads, load
+// balancing with outlier ejection across replicas and a circuit breaker
+// per dependency.
+package grpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/douglasswm/student-cafe-common/logging"
+	"github.com/douglasswm/student-cafe-common/telemetry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/leastrequest"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	_ "google.golang.org/grpc/xds" // registers outlier_detection_experimental
+)
+
+// Method is the call policy for one RPC
+type Method struct {
+	// FullMethod is the gRPC method name, e.g. "/menu.v1.MenuService/GetMenuItem"
+	FullMethod string
+	// Timeout is the default deadline applied when the caller's context
+	// has none (or a later one)
+	Timeout time.Duration
+	// Idempotent methods are retried on transient errors
+	Idempotent bool
+}
+
+// RetryPolicy configures retries of idempotent methods
+type RetryPolicy struct {
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+	// RetryableCodes are gRPC status code names, e.g. "UNAVAILABLE"
+	RetryableCodes []string
+}
+
+// Policy is the client configuration for one dependency
+type Policy struct {
+	// Name identifies the dependency in errors and logs
+	Name    string
+	Methods []Method
+	Retry   RetryPolicy
+	Breaker BreakerSettings
+	// Balancer spreads calls across the endpoints the target resolves to
+	Balancer BalancerSettings
+}
+
+// Load balancing policies built into gRPC, for picking an endpoint for each call
+const (
+	// RoundRobin takes the endpoints in turn
+	RoundRobin = roundrobin.Name
+	// LeastRequest takes the less busy of two random endpoints
+	LeastRequest = leastrequest.Name
+)
+
+// outlierDetection is gRPC's policy that ejects failing endpoints and
+// leaves the rest to the pick policy beneath it
+const outlierDetection = "outlier_detection_experimental"
+
+// BalancerSettings configure load balancing and outlier ejection
+type BalancerSettings struct {
+	// Pick is RoundRobin or LeastRequest
+	Pick string
+	// Interval is how often the endpoints are checked for ejection
+	Interval time.Duration
+	// FailurePercentage ejects an endpoint when more than that share of
+	// its calls failed over the last interval, counting any error status;
+	// 0 never ejects
+	FailurePercentage int
+	// RequestVolume is the fewest calls an endpoint needs in an interval
+	// to be considered for ejection
+	RequestVolume int
+	// BaseEjectionTime is how long an endpoint is first ejected for. Each
+	// further ejection lasts one more BaseEjectionTime, up to MaxEjectionTime
+	BaseEjectionTime time.Duration
+	MaxEjectionTime  time.Duration
+	// MaxEjectionPercent caps the share of endpoints ejected at once, so a
+	// dependency that fails everywhere still gets traffic
+	MaxEjectionPercent int
+}
+
+// DefaultBalancerSettings round-robin and eject an endpoint for 30 seconds
+// when over half its calls in a 10 second interval failed, never ejecting
+// more than half
+var DefaultBalancerSettings = BalancerSettings{
+	Pick:               RoundRobin,
+	Interval:           10 * time.Second,
+	FailurePercentage:  50,
+	RequestVolume:      10,
+	BaseEjectionTime:   30 * time.Second,
+	MaxEjectionTime:    5 * time.Minute,
+	MaxEjectionPercent: 50,
+}
+
+// DefaultRetryPolicy retries idempotent reads on UNAVAILABLE with exponential backoff
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:       3,
+	InitialBackoff:    100 * time.Millisecond,
+	MaxBackoff:        1 * time.Second,
+	BackoffMultiplier: 2,
+	RetryableCodes:    []string{"UNAVAILABLE"},
+}
+
+// DefaultBreakerSettings opens the breaker after 5 consecutive failures for 10 seconds
+var DefaultBreakerSettings = BreakerSettings{
+	FailureThreshold: 5,
+	OpenTimeout:      10 * time.Second,
+}
+
+// Conn is a client connection together with the breaker guarding it
+type Conn struct {
+	*grpc.ClientConn
+	Breaker *Breaker
+}
+
+// HealthConn returns the connection for health probes. Probes bypass the
+// breaker, so readiness follows the dependency's own health rather than
+// the breaker state, and their results don't count towards it.
+func (c *Conn) HealthConn() grpc.ClientConnInterface {
+	return healthConn{c.ClientConn}
+}
+
+// healthConn sends every call past the breaker
+type healthConn struct {
+	*grpc.ClientConn
+}
+
+func (c healthConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return c.ClientConn.Invoke(ctx, method, args, reply, append(opts, skipBreaker{})...)
+}
+
+// Dial creates a client connection to target that applies the policy and
+// traces every call. Transport credentials and any other options are passed through.
+// target is a single address, a comma-separated list of replicas such as
+// "menu-1:9092,menu-2:9092", or a resolver target such as
+// "dns:///menu-service:9092" that may return several addresses.
+func Dial(target string, policy Policy, opts ...grpc.DialOption) (*Conn, error) {
+	serviceConfig, err := policy.ServiceConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if addrs := strings.Split(target, ","); len(addrs) > 1 {
+		r, err := staticResolver(addrs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target %q for %s: %w", target, policy.Name, err)
+		}
+		opts = append(opts, grpc.WithResolvers(r))
+		target = r.Scheme() + ":///" + policy.Name
+	}
+
+	breaker := NewBreaker(policy.Name, policy.Breaker)
+	opts = append([]grpc.DialOption{
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithChainUnaryInterceptor(breaker.UnaryClientInterceptor(), logging.UnaryClientInterceptor()),
+		telemetry.DialOption(),
+	}, opts...)
+
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Conn{ClientConn: conn, Breaker: breaker}, nil
+}
+
+// staticResolver resolves to a fixed list of host:port addresses. Each
+// address keeps its own host name for TLS verification
+func staticResolver(addrs []string) (*manual.Resolver, error) {
+	var state resolver.State
+	for _, addr := range addrs {
+		addr = strings.TrimSpace(addr)
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: addr, ServerName: host})
+	}
+	r := manual.NewBuilderWithScheme("static")
+	r.InitialState(state)
+	return r, nil
+}
+
+// serviceConfig mirrors the parts of the gRPC service config JSON we use
+type serviceConfig struct {
+	LoadBalancingConfig []map[string]outlierDetectionConfig `json:"loadBalancingConfig,omitempty"`
+	MethodConfig        []methodConfig                      `json:"methodConfig"`
+}
+
+type outlierDetectionConfig struct {
+	Interval                  string                     `json:"interval"`
+	BaseEjectionTime          string                     `json:"baseEjectionTime"`
+	MaxEjectionTime           string                     `json:"maxEjectionTime"`
+	MaxEjectionPercent        int                        `json:"maxEjectionPercent"`
+	FailurePercentageEjection *failurePercentageEjection `json:"failurePercentageEjection,omitempty"`
+	ChildPolicy               []map[string]struct{}      `json:"childPolicy"`
+}
+
+type failurePercentageEjection struct {
+	Threshold             int `json:"threshold"`
+	EnforcementPercentage int `json:"enforcementPercentage"`
+	MinimumHosts          int `json:"minimumHosts"`
+	RequestVolume         int `json:"requestVolume"`
+}
+
+type methodConfig struct {
+	Name        []methodName       `json:"name"`
+	Timeout     string             `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicyConfig `json:"retryPolicy,omitempty"`
+}
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method,omitempty"`
+}
+
+type retryPolicyConfig struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+// ServiceConfig renders the policy as a gRPC service config JSON document
+func (p Policy) ServiceConfig() (string, error) {
+	retry := p.Retry
+	if retry.MaxAttempts == 0 {
+		retry = DefaultRetryPolicy
+	}
+
+	balancing := p.Balancer
+	if balancing.Pick == "" {
+		balancing = DefaultBalancerSettings
+	}
+
+	cfg := serviceConfig{LoadBalancingConfig: []map[string]outlierDetectionConfig{{outlierDetection: balancing.config()}}}
+	for _, m := range p.Methods {
+		service, method, ok := strings.Cut(strings.TrimPrefix(m.FullMethod, "/"), "/")
+		if !ok || service == "" || method == "" {
+			return "", fmt.Errorf("invalid method name %q for %s", m.FullMethod, p.Name)
+		}
+
+		mc := methodConfig{Name: []methodName{{Service: service, Method: method}}}
+		if m.Timeout > 0 {
+			mc.Timeout = durationString(m.Timeout)
+		}
+		if m.Idempotent && retry.MaxAttempts > 1 {
+			mc.RetryPolicy = &retryPolicyConfig{
+				MaxAttempts:          retry.MaxAttempts,
+				InitialBackoff:       durationString(retry.InitialBackoff),
+				MaxBackoff:           durationString(retry.MaxBackoff),
+				BackoffMultiplier:    retry.BackoffMultiplier,
+				RetryableStatusCodes: retry.RetryableCodes,
+			}
+		}
+		cfg.MethodConfig = append(cfg.MethodConfig, mc)
+	}
+
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// config renders the settings as an outlier detection config over the pick policy
+func (s BalancerSettings) config() outlierDetectionConfig {
+	cfg := outlierDetectionConfig{
+		Interval:           durationString(s.Interval),
+		BaseEjectionTime:   durationString(s.BaseEjectionTime),
+		MaxEjectionTime:    durationString(s.MaxEjectionTime),
+		MaxEjectionPercent: s.MaxEjectionPercent,
+		ChildPolicy:        []map[string]struct{}{{s.Pick: {}}},
+	}
+	if s.FailurePercentage > 0 {
+		// Two replicas are enough to tell one failing from the other
+		cfg.FailurePercentageEjection = &failurePercentageEjection{
+			Threshold:             s.FailurePercentage,
+			EnforcementPercentage: 100,
+			MinimumHosts:          2,
+			RequestVolume:         s.RequestVolume,
+		}
+	}
+	return cfg
+}
+
+// durationString formats a duration the way the service config expects ("1.5s")
+func durationString(d time.Duration) string {
+	return fmt.Sprintf("%.9gs", d.Seconds())
+}
@@ -0,0 +1,282 @@
+package grpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// faultyMenuServer injects failures and latency into menu-service calls
+type faultyMenuServer struct {
+	menuv1.UnimplementedMenuServiceServer
+	calls    atomic.Int32
+	failures atomic.Int32 // number of upcoming calls that fail with failCode
+	failCode codes.Code
+	delay    time.Duration
+}
+
+func (s *faultyMenuServer) fault(ctx context.Context) error {
+	s.calls.Add(1)
+	if s.failures.Add(-1) >= 0 {
+		return status.Error(s.failCode, "injected fault")
+	}
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (s *faultyMenuServer) GetMenuItem(ctx context.Context, req *menuv1.GetMenuItemRequest) (*menuv1.GetMenuItemResponse, error) {
+	if err := s.fault(ctx); err != nil {
+		return nil, err
+	}
+	return &menuv1.GetMenuItemResponse{MenuItem: &menuv1.MenuItem{Id: req.Id, Name: "Coffee"}}, nil
+}
+
+func (s *faultyMenuServer) CreateMenuItem(ctx context.Context, req *menuv1.CreateMenuItemRequest) (*menuv1.CreateMenuItemResponse, error) {
+	if err := s.fault(ctx); err != nil {
+		return nil, err
+	}
+	return &menuv1.CreateMenuItemResponse{MenuItem: &menuv1.MenuItem{Id: 1, Name: req.Name}}, nil
+}
+
+// startFaultyServer serves fake on a bufconn listener and dials it with policy
+func startFaultyServer(t *testing.T, fake *faultyMenuServer, policy Policy) (*Conn, menuv1.MenuServiceClient) {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	menuv1.RegisterMenuServiceServer(server, fake)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := Dial("passthrough:///bufnet", policy,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, menuv1.NewMenuServiceClient(conn)
+}
+
+// fastPolicy is the menu-service policy with test-friendly timings
+func fastPolicy() Policy {
+	policy := MenuServicePolicy()
+	policy.Methods[0].Timeout = 100 * time.Millisecond
+	policy.Retry.InitialBackoff = time.Millisecond
+	policy.Retry.MaxBackoff = 5 * time.Millisecond
+	policy.Breaker = BreakerSettings{FailureThreshold: 3, OpenTimeout: 100 * time.Millisecond}
+	return policy
+}
+
+func TestPolicyServiceConfig(t *testing.T) {
+	cfg, err := MenuServicePolicy().ServiceConfig()
+	require.NoError(t, err)
+
+	var parsed serviceConfig
+	require.NoError(t, json.Unmarshal([]byte(cfg), &parsed))
+	require.Len(t, parsed.MethodConfig, 4)
+
+	read := parsed.MethodConfig[0]
+	assert.Equal(t, methodName{Service: "menu.v1.MenuService", Method: "GetMenuItem"}, read.Name[0])
+	assert.Equal(t, "2s", read.Timeout)
+	require.NotNil(t, read.RetryPolicy)
+	assert.Equal(t, 3, read.RetryPolicy.MaxAttempts)
+	assert.Equal(t, "0.1s", read.RetryPolicy.InitialBackoff)
+	assert.Equal(t, []string{"UNAVAILABLE"}, read.RetryPolicy.RetryableStatusCodes)
+
+	write := parsed.MethodConfig[2]
+	assert.Equal(t, "CreateMenuItem", write.Name[0].Method)
+	assert.Equal(t, "5s", write.Timeout)
+	assert.Nil(t, write.RetryPolicy, "writes must not be retried")
+
+	_, err = Policy{Name: "bad", Methods: []Method{{FullMethod: "GetMenuItem"}}}.ServiceConfig()
+	assert.Error(t, err)
+}
+
+func TestPolicyServiceConfig_Balancing(t *testing.T) {
+	for _, tt := range []struct {
+		policy Policy
+		pick   string
+	}{
+		{MenuServicePolicy(), RoundRobin},
+		{OrderServicePolicy(), LeastRequest},
+	} {
+		cfg, err := tt.policy.ServiceConfig()
+		require.NoError(t, err)
+
+		var parsed serviceConfig
+		require.NoError(t, json.Unmarshal([]byte(cfg), &parsed))
+		require.Len(t, parsed.LoadBalancingConfig, 1)
+		od, ok := parsed.LoadBalancingConfig[0][outlierDetection]
+		require.True(t, ok, tt.policy.Name)
+		assert.Equal(t, []map[string]struct{}{{tt.pick: {}}}, od.ChildPolicy, tt.policy.Name)
+		assert.Equal(t, "30s", od.BaseEjectionTime)
+		require.NotNil(t, od.FailurePercentageEjection)
+		assert.Equal(t, 50, od.FailurePercentageEjection.Threshold)
+
+		// gRPC must know every policy named, or Dial would fall back to pick_first
+		_, err = balancer.Get(outlierDetection).(balancer.ConfigParser).ParseConfig(json.RawMessage(mustMarshal(t, od)))
+		assert.NoError(t, err, tt.policy.Name)
+		assert.NotNil(t, balancer.Get(tt.pick), tt.pick)
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	return out
+}
+
+func TestStaticResolver(t *testing.T) {
+	r, err := staticResolver([]string{"menu-1:9092", " menu-2:9092"})
+	require.NoError(t, err)
+	assert.Equal(t, "static", r.Scheme())
+
+	_, err = staticResolver([]string{"menu-1:9092", "menu-2"})
+	assert.Error(t, err, "every address needs a port")
+
+	_, err = Dial("menu-1:9092,menu-2", MenuServicePolicy())
+	assert.ErrorContains(t, err, fmt.Sprintf("invalid target %q", "menu-1:9092,menu-2"))
+}
+
+func TestDial_RetriesIdempotentReads(t *testing.T) {
+	fake := &faultyMenuServer{failCode: codes.Unavailable}
+	fake.failures.Store(2)
+	_, client := startFaultyServer(t, fake, fastPolicy())
+
+	resp, err := client.GetMenuItem(context.Background(), &menuv1.GetMenuItemRequest{Id: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Coffee", resp.MenuItem.Name)
+	assert.Equal(t, int32(3), fake.calls.Load())
+}
+
+func TestDial_DoesNotRetryWrites(t *testing.T) {
+	fake := &faultyMenuServer{failCode: codes.Unavailable}
+	fake.failures.Store(1)
+	_, client := startFaultyServer(t, fake, fastPolicy())
+
+	_, err := client.CreateMenuItem(context.Background(), &menuv1.CreateMenuItemRequest{Name: "Tea"})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(1), fake.calls.Load())
+}
+
+func TestDial_DoesNotRetryNonTransientErrors(t *testing.T) {
+	fake := &faultyMenuServer{failCode: codes.NotFound}
+	fake.failures.Store(1)
+	_, client := startFaultyServer(t, fake, fastPolicy())
+
+	_, err := client.GetMenuItem(context.Background(), &menuv1.GetMenuItemRequest{Id: 1})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, int32(1), fake.calls.Load())
+}
+
+func TestDial_AppliesDefaultDeadline(t *testing.T) {
+	fake := &faultyMenuServer{delay: time.Second}
+	_, client := startFaultyServer(t, fake, fastPolicy())
+
+	start := time.Now()
+	_, err := client.GetMenuItem(context.Background(), &menuv1.GetMenuItemRequest{Id: 1})
+
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestDial_CallerDeadlineWins(t *testing.T) {
+	fake := &faultyMenuServer{delay: 50 * time.Millisecond}
+	policy := fastPolicy()
+	policy.Methods[0].Timeout = time.Second
+	_, client := startFaultyServer(t, fake, policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 1})
+
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestDial_CircuitBreakerFailsFast(t *testing.T) {
+	fake := &faultyMenuServer{failCode: codes.Unavailable}
+	fake.failures.Store(1000)
+	policy := fastPolicy()
+	policy.Retry.MaxAttempts = 1
+	conn, client := startFaultyServer(t, fake, policy)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 1})
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	require.Equal(t, StateOpen, conn.Breaker.State())
+
+	// While open, calls fail fast without reaching the server
+	_, err := client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 1})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "circuit breaker open")
+	assert.Equal(t, int32(3), fake.calls.Load())
+
+	// Once the dependency recovers, the probe after the open timeout closes the breaker
+	fake.failures.Store(0)
+	time.Sleep(policy.Breaker.OpenTimeout)
+	_, err = client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, StateClosed, conn.Breaker.State())
+}
+
+func TestHealthConn_BypassesBreaker(t *testing.T) {
+	fake := &faultyMenuServer{failCode: codes.Unavailable}
+	fake.failures.Store(3)
+	policy := fastPolicy()
+	policy.Retry.MaxAttempts = 1
+	conn, client := startFaultyServer(t, fake, policy)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 1})
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	require.Equal(t, StateOpen, conn.Breaker.State())
+
+	// Probes reach the recovered server while the breaker is open, and
+	// don't close it
+	_, err := menuv1.NewMenuServiceClient(conn.HealthConn()).GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), fake.calls.Load())
+	assert.Equal(t, StateOpen, conn.Breaker.State())
+}
+
+func TestDial_CallerErrorsDoNotTripBreaker(t *testing.T) {
+	fake := &faultyMenuServer{failCode: codes.InvalidArgument}
+	fake.failures.Store(1000)
+	conn, client := startFaultyServer(t, fake, fastPolicy())
+
+	for i := 0; i < 5; i++ {
+		_, err := client.GetMenuItem(context.Background(), &menuv1.GetMenuItemRequest{Id: 1})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+	assert.Equal(t, StateClosed, conn.Breaker.State())
+}
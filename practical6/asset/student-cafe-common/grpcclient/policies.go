@@ -0,0 +1,70 @@
+package grpcclient
+
+import (
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+)
+
+// Default deadlines for reads and writes between the cafe services. They
+// cap the gateway's route timeouts: a call ends at whichever comes first
+const (
+	ReadTimeout  = 2 * time.Second
+	WriteTimeout = 5 * time.Second
+	// ReceiptTimeout allows for rendering PDF receipts of large orders
+	ReceiptTimeout = 20 * time.Second
+)
+
+// UserServicePolicy is the client policy for user-service
+func UserServicePolicy() Policy {
+	return Policy{
+		Name: "user-service",
+		Methods: []Method{
+			{FullMethod: userv1.UserService_GetUser_FullMethodName, Timeout: ReadTimeout, Idempotent: true},
+			{FullMethod: userv1.UserService_GetUsers_FullMethodName, Timeout: ReadTimeout, Idempotent: true},
+			{FullMethod: userv1.UserService_CreateUser_FullMethodName, Timeout: WriteTimeout},
+		},
+		Retry:    DefaultRetryPolicy,
+		Breaker:  DefaultBreakerSettings,
+		Balancer: DefaultBalancerSettings,
+	}
+}
+
+// MenuServicePolicy is the client policy for menu-service
+func MenuServicePolicy() Policy {
+	return Policy{
+		Name: "menu-service",
+		Methods: []Method{
+			{FullMethod: menuv1.MenuService_GetMenuItem_FullMethodName, Timeout: ReadTimeout, Idempotent: true},
+			{FullMethod: menuv1.MenuService_GetMenu_FullMethodName, Timeout: ReadTimeout, Idempotent: true},
+			{FullMethod: menuv1.MenuService_CreateMenuItem_FullMethodName, Timeout: WriteTimeout},
+			{FullMethod: menuv1.MenuService_SetMenuItemStock_FullMethodName, Timeout: WriteTimeout},
+		},
+		Retry:    DefaultRetryPolicy,
+		Breaker:  DefaultBreakerSettings,
+		Balancer: DefaultBalancerSettings,
+	}
+}
+
+// OrderServicePolicy is the client policy for order-service. CreateOrder
+// calls out to user-service and menu-service, so it gets a longer deadline,
+// and as call durations vary, calls go to the replica with fewer in flight.
+func OrderServicePolicy() Policy {
+	balancing := DefaultBalancerSettings
+	balancing.Pick = LeastRequest
+	return Policy{
+		Name: "order-service",
+		Methods: []Method{
+			{FullMethod: orderv1.OrderService_GetOrder_FullMethodName, Timeout: ReadTimeout, Idempotent: true},
+			{FullMethod: orderv1.OrderService_GetOrders_FullMethodName, Timeout: ReadTimeout, Idempotent: true},
+			{FullMethod: orderv1.OrderService_GetOrderReceipt_FullMethodName, Timeout: ReceiptTimeout, Idempotent: true},
+			{FullMethod: orderv1.OrderService_CreateOrder_FullMethodName, Timeout: 2 * WriteTimeout},
+			{FullMethod: orderv1.OrderService_AmendOrder_FullMethodName, Timeout: 2 * WriteTimeout},
+		},
+		Retry:    DefaultRetryPolicy,
+		Breaker:  DefaultBreakerSettings,
+		Balancer: balancing,
+	}
+}
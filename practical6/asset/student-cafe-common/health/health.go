@@ -0,0 +1,185 @@
+// Package health implements the grpc.health.v1 protocol for the cafe
+// services, reporting SERVING only while the service's database and the
+// services it depends on are reachable.
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gorm.io/gorm"
+)
+
+// Default polling settings for Monitor
+const (
+	DefaultInterval = 5 * time.Second
+	DefaultTimeout  = 2 * time.Second
+)
+
+// Check probes one dependency, returning an error when it is unhealthy
+type Check struct {
+	Name  string
+	Probe func(ctx context.Context) error
+}
+
+// DBCheck pings the database behind db
+func DBCheck(db *gorm.DB) Check {
+	return Check{
+		Name: "database",
+		Probe: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	}
+}
+
+// GRPCCheck asks the grpc.health.v1 server behind conn for its overall
+// status. For a grpcclient.Conn pass its HealthConn, so the probe is not
+// rejected while the circuit breaker is open.
+func GRPCCheck(name string, conn grpc.ClientConnInterface) Check {
+	client := healthpb.NewHealthClient(conn)
+	return Check{
+		Name: name,
+		Probe: func(ctx context.Context) error {
+			resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+			if err != nil {
+				return err
+			}
+			if resp.Status != healthpb.HealthCheckResponse_SERVING {
+				return fmt.Errorf("%s is %s", name, resp.Status)
+			}
+			return nil
+		},
+	}
+}
+
+// RunChecks runs checks concurrently, each bounded by timeout, and returns
+// the result of each by name (nil for healthy)
+func RunChecks(ctx context.Context, timeout time.Duration, checks []Check) map[string]error {
+	results := make(map[string]error, len(checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			err := check.Probe(checkCtx)
+			mu.Lock()
+			results[check.Name] = err
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+	return results
+}
+
+// Monitor serves grpc.health.v1 and keeps the status of the overall server
+// ("") and each named service in step with its checks. Everything starts
+// NOT_SERVING until the first round of checks passes.
+type Monitor struct {
+	server   *grpchealth.Server
+	services []string
+	checks   []Check
+	Interval time.Duration
+	Timeout  time.Duration
+
+	mu       sync.Mutex
+	serving  bool
+	shutdown bool
+}
+
+// NewMonitor creates a monitor reporting for services (full gRPC service
+// names such as "menu.v1.MenuService") based on checks
+func NewMonitor(services []string, checks ...Check) *Monitor {
+	m := &Monitor{
+		server:   grpchealth.NewServer(),
+		services: services,
+		checks:   checks,
+		Interval: DefaultInterval,
+		Timeout:  DefaultTimeout,
+	}
+	m.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return m
+}
+
+// Register registers the health service on s
+func (m *Monitor) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, m.server)
+}
+
+// CheckNow runs every check once and updates the served status, returning
+// true when all checks passed
+func (m *Monitor) CheckNow(ctx context.Context) bool {
+	results := RunChecks(ctx, m.Timeout, m.checks)
+	healthy := true
+	for name, err := range results {
+		if err != nil {
+			healthy = false
+			slog.Warn("Health check failed", "check", name, "error", err)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.shutdown || healthy == m.serving {
+		return healthy
+	}
+	m.serving = healthy
+	if healthy {
+		slog.Info("Health status changed", "status", "SERVING")
+		m.setStatus(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		slog.Warn("Health status changed", "status", "NOT_SERVING")
+		m.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return healthy
+}
+
+// Serving reports whether the last round of checks passed and shutdown
+// hasn't started
+func (m *Monitor) Serving() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.serving && !m.shutdown
+}
+
+// Run checks immediately and then every Interval until ctx is done
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+	for {
+		m.CheckNow(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown permanently marks everything NOT_SERVING so clients stop
+// sending new requests
+func (m *Monitor) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shutdown = true
+	m.server.Shutdown()
+}
+
+func (m *Monitor) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	m.server.SetServingStatus("", status)
+	for _, service := range m.services {
+		m.server.SetServingStatus(service, status)
+	}
+}
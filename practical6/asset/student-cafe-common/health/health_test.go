@@ -0,0 +1,129 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const menuService = "menu.v1.MenuService"
+
+// serveMonitor serves m over bufconn and returns a client connection to it
+func serveMonitor(t *testing.T, m *Monitor) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	m.Register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func statusOf(t *testing.T, conn *grpc.ClientConn, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.Status
+}
+
+func TestMonitorFollowsChecks(t *testing.T) {
+	var failing atomic.Bool
+	check := Check{Name: "database", Probe: func(ctx context.Context) error {
+		if failing.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	}}
+
+	m := NewMonitor([]string{menuService}, check)
+	conn := serveMonitor(t, m)
+	ctx := context.Background()
+
+	// Not serving until the first checks pass
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, conn, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, conn, menuService))
+
+	assert.True(t, m.CheckNow(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(t, conn, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(t, conn, menuService))
+
+	failing.Store(true)
+	assert.False(t, m.CheckNow(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, conn, menuService))
+
+	failing.Store(false)
+	assert.True(t, m.CheckNow(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(t, conn, menuService))
+
+	// Shutdown sticks even if checks keep passing
+	m.Shutdown()
+	m.CheckNow(ctx)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, conn, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, conn, menuService))
+}
+
+func TestGRPCCheckFollowsDependency(t *testing.T) {
+	var failing atomic.Bool
+	dependency := NewMonitor(nil, Check{Name: "database", Probe: func(ctx context.Context) error {
+		if failing.Load() {
+			return errors.New("down")
+		}
+		return nil
+	}})
+	conn := serveMonitor(t, dependency)
+
+	// A service depending on it is only healthy while the dependency is
+	m := NewMonitor([]string{"order.v1.OrderService"}, GRPCCheck("menu-service", conn))
+	ctx := context.Background()
+
+	assert.False(t, m.CheckNow(ctx), "dependency not yet serving")
+	dependency.CheckNow(ctx)
+	assert.True(t, m.CheckNow(ctx))
+	failing.Store(true)
+	dependency.CheckNow(ctx)
+	assert.False(t, m.CheckNow(ctx))
+}
+
+func TestDBCheck(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	check := DBCheck(db)
+
+	assert.NoError(t, check.Probe(context.Background()))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.Close()
+	assert.Error(t, check.Probe(context.Background()))
+}
+
+func TestRunChecksTimesOut(t *testing.T) {
+	slow := Check{Name: "slow", Probe: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	ok := Check{Name: "ok", Probe: func(ctx context.Context) error { return nil }}
+
+	results := RunChecks(context.Background(), 20*time.Millisecond, []Check{slow, ok})
+	assert.ErrorIs(t, results["slow"], context.DeadlineExceeded)
+	assert.NoError(t, results["ok"])
+}
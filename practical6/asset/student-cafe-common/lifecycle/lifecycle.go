@@ -0,0 +1,40 @@
+// Package lifecycle handles termination signals and draining of the cafe
+// services' servers so deploys don't drop in-flight requests.
+package lifecycle
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// SignalContext returns a context cancelled on SIGINT or SIGTERM
+func SignalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// GracefulStop stops s from accepting new RPCs and waits up to timeout for
+// in-flight ones to finish, then forces the rest closed. It reports whether
+// everything drained in time.
+func GracefulStop(s *grpc.Server, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		slog.Warn("In-flight RPCs did not finish in time, forcing stop", "timeout", timeout.String())
+		s.Stop()
+		<-done
+		return false
+	}
+}
@@ -0,0 +1,90 @@
+package lifecycle
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// slowMenuServer answers GetMenu once release is closed
+type slowMenuServer struct {
+	menuv1.UnimplementedMenuServiceServer
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *slowMenuServer) GetMenu(ctx context.Context, req *menuv1.GetMenuRequest) (*menuv1.GetMenuResponse, error) {
+	s.started <- struct{}{}
+	select {
+	case <-s.release:
+		return &menuv1.GetMenuResponse{}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func startServer(t *testing.T) (*grpc.Server, menuv1.MenuServiceClient, *slowMenuServer) {
+	backend := &slowMenuServer{started: make(chan struct{}, 1), release: make(chan struct{})}
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	menuv1.RegisterMenuServiceServer(server, backend)
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return server, menuv1.NewMenuServiceClient(conn), backend
+}
+
+func TestGracefulStopWaitsForInFlightRPC(t *testing.T) {
+	server, client, backend := startServer(t)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.GetMenu(context.Background(), &menuv1.GetMenuRequest{})
+		result <- err
+	}()
+	<-backend.started
+
+	stopped := make(chan bool, 1)
+	go func() { stopped <- GracefulStop(server, 5*time.Second) }()
+
+	// Still draining while the RPC is in flight
+	select {
+	case <-stopped:
+		t.Fatal("GracefulStop returned before the in-flight RPC finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(backend.release)
+	assert.NoError(t, <-result)
+	assert.True(t, <-stopped)
+}
+
+func TestGracefulStopForcesAfterTimeout(t *testing.T) {
+	server, client, backend := startServer(t)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.GetMenu(context.Background(), &menuv1.GetMenuRequest{})
+		result <- err
+	}()
+	<-backend.started
+
+	start := time.Now()
+	assert.False(t, GracefulStop(server, 50*time.Millisecond))
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Error(t, <-result)
+}
@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// DefaultSlowQuery is how long a query may take before it is logged as slow
+const DefaultSlowQuery = 200 * time.Millisecond
+
+// GORMLogger writes GORM's logs through slog with the query's context, so
+// queries run with db.WithContext(ctx) carry the request and trace IDs.
+// Queries are logged at debug level, slow ones as warnings and failed ones
+// as errors; a missing record is not treated as a failure.
+type GORMLogger struct {
+	// Logger receives the logs; nil means slog.Default()
+	Logger *slog.Logger
+	// SlowQuery is the threshold for slow queries; 0 means DefaultSlowQuery
+	SlowQuery time.Duration
+	silent    bool
+}
+
+// NewGORMLogger creates a GORMLogger writing to the default logger
+func NewGORMLogger() *GORMLogger {
+	return &GORMLogger{}
+}
+
+// LogMode implements gormlogger.Interface. Only Silent is honoured; the
+// slog level decides everything else
+func (l *GORMLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.silent = level == gormlogger.Silent
+	return &copied
+}
+
+// Info implements gormlogger.Interface
+func (l *GORMLogger) Info(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelInfo, fmt.Sprintf(msg, args...))
+}
+
+// Warn implements gormlogger.Interface
+func (l *GORMLogger) Warn(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelWarn, fmt.Sprintf(msg, args...))
+}
+
+// Error implements gormlogger.Interface
+func (l *GORMLogger) Error(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelError, fmt.Sprintf(msg, args...))
+}
+
+// Trace implements gormlogger.Interface by logging one finished query
+func (l *GORMLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	slow := l.SlowQuery
+	if slow <= 0 {
+		slow = DefaultSlowQuery
+	}
+
+	level, msg := slog.LevelDebug, "Query"
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level, msg = slog.LevelError, "Query failed"
+	case elapsed > slow:
+		level, msg = slog.LevelWarn, "Slow query"
+	}
+	logger := l.logger()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{slog.String("sql", sql), slog.Int64("rows", rows), slog.Duration("duration", elapsed)}
+	if level == slog.LevelError {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+func (l *GORMLogger) log(ctx context.Context, level slog.Level, msg string) {
+	if l.silent {
+		return
+	}
+	l.logger().Log(ctx, level, msg)
+}
+
+func (l *GORMLogger) logger() *slog.Logger {
+	if l.Logger != nil {
+		return l.Logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,128 @@
+// Package logging sets up structured JSON logging for the cafe services.
+// Every line carries the service name and, when logged with a request
+// context, the request ID and trace ID so one request can be followed
+// across the gateway and backends.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Output formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config selects the service name, minimum level and output format
+type Config struct {
+	Service string
+	Level   slog.Level
+	Format  string
+	Output  io.Writer
+}
+
+// level is the minimum level of the default logger installed by Setup,
+// changeable at runtime with SetLevel
+var level = new(slog.LevelVar)
+
+// ParseLevel parses "debug", "info", "warn" or "error" (case-insensitive)
+func ParseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(s)); err != nil {
+		return l, fmt.Errorf("invalid log level %q: want debug, info, warn or error", s)
+	}
+	return l, nil
+}
+
+// ValidFormat reports whether format is a supported output format
+func ValidFormat(format string) bool {
+	return format == FormatJSON || format == FormatText
+}
+
+// New creates a logger for cfg
+func New(cfg Config) *slog.Logger {
+	return newLogger(cfg, cfg.Level)
+}
+
+// Setup creates the logger for cfg and installs it as the default, so the
+// standard log package also writes through it. Its level can later be
+// changed with SetLevel.
+func Setup(cfg Config) *slog.Logger {
+	level.Set(cfg.Level)
+	logger := newLogger(cfg, level)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// SetLevel changes the minimum level of the logger installed by Setup
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+func newLogger(cfg Config, leveler slog.Leveler) *slog.Logger {
+	out := cfg.Output
+	if out == nil {
+		out = os.Stderr
+	}
+	opts := &slog.HandlerOptions{Level: leveler}
+
+	var handler slog.Handler
+	if cfg.Format == FormatText {
+		handler = slog.NewTextHandler(out, opts)
+	} else {
+		handler = slog.NewJSONHandler(out, opts)
+	}
+	return slog.New(ContextHandler{handler}).With("service", cfg.Service)
+}
+
+// Fatal logs at error level and exits
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+type requestIDKey struct{}
+
+// RequestID returns the request ID stored in ctx, or "" if there is none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// ContextHandler adds the request ID and trace ID from the record's context
+type ContextHandler struct {
+	slog.Handler
+}
+
+// Handle implements slog.Handler
+func (h ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler
+func (h ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return ContextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler
+func (h ContextHandler) WithGroup(name string) slog.Handler {
+	return ContextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,194 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// decodeLines parses each JSON log line written to buf
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestContextFieldsAreLogged(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Service: "menu-service", Level: slog.LevelInfo, Format: FormatJSON, Output: &buf})
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+	ctx = WithRequestID(ctx, "req-123")
+
+	logger.InfoContext(ctx, "hello")
+	logger.Debug("hidden below info")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "hello", lines[0]["msg"])
+	assert.Equal(t, "menu-service", lines[0]["service"])
+	assert.Equal(t, "req-123", lines[0]["request_id"])
+	assert.Equal(t, span.SpanContext().TraceID().String(), lines[0]["trace_id"])
+}
+
+func TestGORMLoggerUsesQueryContext(t *testing.T) {
+	var buf bytes.Buffer
+	gl := &GORMLogger{Logger: New(Config{Service: "menu-service", Level: slog.LevelInfo, Format: FormatJSON, Output: &buf})}
+	ctx := WithRequestID(context.Background(), "req-123")
+	query := func() (string, int64) { return "SELECT * FROM menu_items", 1 }
+
+	gl.Trace(ctx, time.Now(), query, nil)                                        // debug, below info
+	gl.Trace(ctx, time.Now(), query, gorm.ErrRecordNotFound)                     // not a failure
+	gl.Trace(ctx, time.Now().Add(-time.Second), query, nil)                      // slow
+	gl.Trace(ctx, time.Now(), query, errors.New("relation does not exist"))      // failed
+	gl.LogMode(gormlogger.Silent).Trace(ctx, time.Now(), query, errors.New("x")) // silenced
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Slow query", lines[0]["msg"])
+	assert.Equal(t, "WARN", lines[0]["level"])
+	assert.Equal(t, "Query failed", lines[1]["msg"])
+	assert.Equal(t, "relation does not exist", lines[1]["error"])
+	for _, line := range lines {
+		assert.Equal(t, "req-123", line["request_id"])
+		assert.Equal(t, "SELECT * FROM menu_items", line["sql"])
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	l, err := ParseLevel("DEBUG")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelDebug, l)
+
+	_, err = ParseLevel("loud")
+	assert.Error(t, err)
+}
+
+func TestSetLevelChangesDefaultLogger(t *testing.T) {
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+
+	var buf bytes.Buffer
+	logger := Setup(Config{Service: "svc", Level: slog.LevelInfo, Format: FormatJSON, Output: &buf})
+	logger.Debug("dropped")
+	SetLevel(slog.LevelDebug)
+	defer SetLevel(slog.LevelInfo)
+	logger.Debug("kept")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "kept", lines[0]["msg"])
+}
+
+type menuServer struct {
+	menuv1.UnimplementedMenuServiceServer
+	seen chan string
+}
+
+func (s menuServer) GetMenuItem(ctx context.Context, req *menuv1.GetMenuItemRequest) (*menuv1.GetMenuItemResponse, error) {
+	s.seen <- RequestID(ctx)
+	if req.Id == 0 {
+		return nil, status.Error(codes.Internal, "database unavailable")
+	}
+	return &menuv1.GetMenuItemResponse{MenuItem: &menuv1.MenuItem{Id: req.Id}}, nil
+}
+
+func TestGRPCInterceptorsCarryRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Service: "menu-service", Format: FormatJSON, Output: &buf})
+
+	backend := menuServer{seen: make(chan string, 2)}
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(ServerOptions(logger)...)
+	menuv1.RegisterMenuServiceServer(server, backend)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := menuv1.NewMenuServiceClient(conn)
+
+	// The client interceptor forwards the caller's request ID
+	ctx := WithRequestID(context.Background(), "req-abc")
+	_, err = client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "req-abc", <-backend.seen)
+
+	// An ID already in the outgoing metadata wins
+	ctx = metadata.AppendToOutgoingContext(ctx, MetadataRequestID, "req-gateway")
+	_, err = client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 0})
+	require.Error(t, err)
+	assert.Equal(t, "req-gateway", <-backend.seen)
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "INFO", lines[0]["level"])
+	assert.Equal(t, "rpc finished", lines[0]["msg"])
+	assert.Equal(t, "menu-service", lines[0]["service"])
+	assert.Equal(t, "req-abc", lines[0]["request_id"])
+	assert.Equal(t, "/menu.v1.MenuService/GetMenuItem", lines[0]["grpc.method"])
+	assert.Equal(t, "OK", lines[0]["grpc.code"])
+	assert.Contains(t, lines[0], "latency_ms")
+
+	assert.Equal(t, "ERROR", lines[1]["level"])
+	assert.Equal(t, "Internal", lines[1]["grpc.code"])
+	assert.Equal(t, "database unavailable", lines[1]["error"])
+	assert.Equal(t, "req-gateway", lines[1]["request_id"])
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Service: "api-gateway", Format: FormatJSON, Output: &buf})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "order not found", http.StatusNotFound)
+	})
+	handler := Middleware(logger, func(r *http.Request) string { return r.Pattern })(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/orders/7", nil)
+	req = req.WithContext(WithRequestID(req.Context(), "req-http"))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "req-http", lines[0]["request_id"])
+	assert.Equal(t, "GET /api/orders/{id}", lines[0]["http.route"])
+	assert.Equal(t, "/api/orders/7", lines[0]["http.path"])
+	assert.Equal(t, float64(http.StatusNotFound), lines[0]["http.status"])
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewAdminMux returns the admin HTTP handler serving /metrics from gatherer
+func NewAdminMux(gatherer prometheus.Gatherer) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	return mux
+}
+
+// ServeAdmin starts the admin listener on addr in the background and returns
+// the server with Addr set to the bound address. It is kept separate from
+// the service port so deployments can keep metrics off the public network;
+// addr itself may listen on every interface (e.g. ":9101") so scrapers on
+// the internal network can reach it.
+func ServeAdmin(addr string, handler http.Handler) (*http.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &http.Server{Addr: lis.Addr().String(), Handler: handler, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Admin server failed", "error", err)
+		}
+	}()
+	slog.Info("Admin server (metrics) listening", "addr", srv.Addr)
+	return srv, nil
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type menuServer struct {
+	menuv1.UnimplementedMenuServiceServer
+}
+
+func (menuServer) GetMenuItem(ctx context.Context, req *menuv1.GetMenuItemRequest) (*menuv1.GetMenuItemResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.NotFound, "menu item not found")
+	}
+	return &menuv1.GetMenuItemResponse{MenuItem: &menuv1.MenuItem{Id: req.Id}}, nil
+}
+
+func TestGRPCServerMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewGRPCServerMetrics(reg)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(m.ServerOptions()...)
+	menuv1.RegisterMenuServiceServer(server, menuServer{})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := menuv1.NewMenuServiceClient(conn)
+
+	ctx := context.Background()
+	_, err = client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 1})
+	require.NoError(t, err)
+	_, err = client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 1})
+	require.NoError(t, err)
+	_, err = client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: 0})
+	require.Error(t, err)
+	_, err = client.GetMenu(ctx, &menuv1.GetMenuRequest{})
+	require.Error(t, err)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.handled.WithLabelValues("menu.v1.MenuService", "GetMenuItem", "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.handled.WithLabelValues("menu.v1.MenuService", "GetMenuItem", "NotFound")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.handled.WithLabelValues("menu.v1.MenuService", "GetMenu", "Unimplemented")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.duration))
+}
+
+func TestHTTPMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewHTTPMetrics(reg)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "0" {
+			http.Error(w, "order not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+	handler := m.Middleware(func(r *http.Request) string { return r.Pattern })(mux)
+
+	for _, path := range []string{"/api/orders/1", "/api/orders/2", "/api/orders/0", "/nope/123"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Labels use the route pattern, never the raw path
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "GET /api/orders/{id}", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "GET /api/orders/{id}", "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "unmatched", "404")))
+}
+
+func TestAdminMuxServesMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "cafe_test_total", Help: "Test counter."})
+	reg.MustRegister(counter)
+	counter.Add(3)
+
+	srv, err := ServeAdmin("127.0.0.1:0", NewAdminMux(reg))
+	require.NoError(t, err)
+	defer srv.Close()
+
+	resp, err := http.Get("http://" + srv.Addr + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "cafe_test_total 3")
+}
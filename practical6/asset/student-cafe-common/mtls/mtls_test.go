@@ -0,0 +1,208 @@
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+)
+
+// writeCerts issues a certificate for each name into dir
+func writeCerts(t *testing.T, dir string, ca *CA, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		certPEM, keyPEM, err := ca.Issue(name, []string{name, "localhost", "127.0.0.1"}, time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, WriteFile(filepath.Join(dir, name+".pem"), certPEM, 0o644))
+		require.NoError(t, WriteFile(filepath.Join(dir, name+"-key.pem"), keyPEM, 0o600))
+	}
+}
+
+func files(dir, name string) Config {
+	return Config{
+		CertFile: filepath.Join(dir, name+".pem"),
+		KeyFile:  filepath.Join(dir, name+"-key.pem"),
+		CAFile:   filepath.Join(dir, "ca.pem"),
+	}
+}
+
+func newCA(t *testing.T, dir string) *CA {
+	t.Helper()
+	ca, err := NewCA("Student Cafe Test CA", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, WriteFile(filepath.Join(dir, "ca.pem"), ca.CertPEM(), 0o644))
+	return ca
+}
+
+// serve starts a health server with cfg's server credentials
+func serve(t *testing.T, cfg Config) string {
+	t.Helper()
+	creds, err := ServerCredentials(cfg)
+	require.NoError(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(s, grpchealth.NewServer())
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	_, port, _ := net.SplitHostPort(lis.Addr().String())
+	return "localhost:" + port
+}
+
+// check calls the health service at addr, returning the serial number of
+// the server's certificate
+func check(t *testing.T, addr string, creds credentials.TransportCredentials) (string, error) {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var p peer.Peer
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Peer(&p)); err != nil {
+		return "", err
+	}
+	info := p.AuthInfo.(credentials.TLSInfo)
+	return info.State.PeerCertificates[0].SerialNumber.String(), nil
+}
+
+func clientCreds(t *testing.T, cfg Config) credentials.TransportCredentials {
+	t.Helper()
+	creds, err := ClientCredentials(cfg)
+	require.NoError(t, err)
+	return creds
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t, dir)
+	writeCerts(t, dir, ca, "menu-service", "api-gateway", "cafectl")
+
+	server := files(dir, "menu-service")
+	server.AllowedClients = []string{"api-gateway"}
+	addr := serve(t, server)
+
+	_, err := check(t, addr, clientCreds(t, files(dir, "api-gateway")))
+	assert.NoError(t, err, "allowed client")
+
+	_, err = check(t, addr, clientCreds(t, files(dir, "cafectl")))
+	assert.Error(t, err, "client not in the allowed list")
+
+	_, err = check(t, addr, credentials.NewTLS(caOnly(t, dir)))
+	assert.Error(t, err, "client without a certificate")
+
+	otherDir := t.TempDir()
+	writeCerts(t, otherDir, newCA(t, otherDir), "api-gateway")
+	_, err = check(t, addr, clientCreds(t, files(otherDir, "api-gateway")))
+	assert.Error(t, err, "client signed by another CA")
+}
+
+// caOnly returns a client config that trusts the CA but has no certificate
+func caOnly(t *testing.T, dir string) *tls.Config {
+	t.Helper()
+	caPEM, err := os.ReadFile(filepath.Join(dir, "ca.pem"))
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caPEM))
+	return &tls.Config{RootCAs: pool}
+}
+
+func TestServerNameIsVerified(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t, dir)
+	writeCerts(t, dir, ca, "api-gateway")
+	// Issued without localhost, so dialing localhost must fail
+	certPEM, keyPEM, err := ca.Issue("menu-service", []string{"menu-service"}, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, WriteFile(filepath.Join(dir, "menu-service.pem"), certPEM, 0o644))
+	require.NoError(t, WriteFile(filepath.Join(dir, "menu-service-key.pem"), keyPEM, 0o600))
+
+	addr := serve(t, files(dir, "menu-service"))
+	_, err = check(t, addr, clientCreds(t, files(dir, "api-gateway")))
+	assert.ErrorContains(t, err, "certificate is valid for menu-service, not localhost")
+}
+
+func TestRotationWithoutRestart(t *testing.T) {
+	old := checkInterval
+	checkInterval = 0
+	t.Cleanup(func() { checkInterval = old })
+
+	dir := t.TempDir()
+	ca := newCA(t, dir)
+	writeCerts(t, dir, ca, "menu-service", "api-gateway")
+	addr := serve(t, files(dir, "menu-service"))
+	gateway := clientCreds(t, files(dir, "api-gateway"))
+
+	before, err := check(t, addr, gateway)
+	require.NoError(t, err)
+
+	// Rotate to a new CA, trusting both while the certificates change
+	newer, err := NewCA("Student Cafe Test CA 2", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, WriteFile(filepath.Join(dir, "ca.pem"), append(newer.CertPEM(), ca.CertPEM()...), 0o644))
+	writeCerts(t, dir, newer, "menu-service", "api-gateway")
+
+	after, err := check(t, addr, gateway)
+	require.NoError(t, err)
+	assert.NotEqual(t, before, after, "the server should present its new certificate")
+
+	// Once the old CA is dropped, only the new certificates are accepted
+	require.NoError(t, WriteFile(filepath.Join(dir, "ca.pem"), newer.CertPEM(), 0o644))
+	_, err = check(t, addr, gateway)
+	assert.NoError(t, err)
+}
+
+func TestBadRotationKeepsCurrentCertificates(t *testing.T) {
+	old := checkInterval
+	checkInterval = 0
+	t.Cleanup(func() { checkInterval = old })
+
+	dir := t.TempDir()
+	ca := newCA(t, dir)
+	writeCerts(t, dir, ca, "menu-service", "api-gateway")
+	addr := serve(t, files(dir, "menu-service"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "menu-service.pem"), []byte("not a certificate"), 0o644))
+	_, err := check(t, addr, clientCreds(t, files(dir, "api-gateway")))
+	assert.NoError(t, err)
+}
+
+func TestLoadCA(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t, dir)
+	keyPEM, err := ca.KeyPEM()
+	require.NoError(t, err)
+	require.NoError(t, WriteFile(filepath.Join(dir, "ca-key.pem"), keyPEM, 0o600))
+
+	loaded, err := LoadCA(filepath.Join(dir, "ca.pem"), filepath.Join(dir, "ca-key.pem"))
+	require.NoError(t, err)
+	assert.Equal(t, ca.Cert.Raw, loaded.Cert.Raw)
+
+	writeCerts(t, dir, loaded, "user-service")
+	_, err = LoadCA(filepath.Join(dir, "user-service.pem"), filepath.Join(dir, "user-service-key.pem"))
+	assert.ErrorContains(t, err, "not a CA certificate")
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.NoError(t, Config{CertFile: "c", KeyFile: "k", CAFile: "ca", AllowedClients: []string{"api-gateway"}}.Validate())
+	assert.ErrorContains(t, Config{CertFile: "c"}.Validate(), "missing [CA key]")
+	assert.Error(t, Config{AllowedClients: []string{"api-gateway"}}.Validate())
+
+	creds, err := ServerCredentials(Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "insecure", creds.Info().SecurityProtocol)
+}
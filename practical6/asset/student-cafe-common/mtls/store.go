@@ -0,0 +1,108 @@
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkInterval bounds how often handshakes look for changed files
+var checkInterval = time.Second
+
+// store holds the current certificate and CA pool, reloading them when
+// the files change. A rotation that can't be loaded, such as a cert
+// written before its key, is logged and the previous files kept in use
+// until the next check
+type store struct {
+	cfg Config
+
+	mu      sync.Mutex
+	checked time.Time
+	stamp   [3]fileStamp
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+// fileStamp identifies one version of a file
+type fileStamp struct {
+	mod  time.Time
+	size int64
+}
+
+func newStore(cfg Config) (*store, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	s := &store{cfg: cfg}
+	stamp, err := s.stamps()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.load(stamp); err != nil {
+		return nil, err
+	}
+	s.checked = time.Now()
+	return s, nil
+}
+
+// get returns the certificate and CA pool to use for a handshake
+func (s *store) get() (*tls.Certificate, *x509.CertPool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.checked) >= checkInterval {
+		s.checked = time.Now()
+		s.refresh()
+	}
+	return s.cert, s.pool
+}
+
+// refresh reloads the files if any changed since they were last loaded
+func (s *store) refresh() {
+	stamp, err := s.stamps()
+	if err != nil {
+		slog.Warn("Failed to check TLS files, keeping current certificates", "error", err)
+		return
+	}
+	if stamp == s.stamp {
+		return
+	}
+	if err := s.load(stamp); err != nil {
+		slog.Warn("Failed to reload TLS files, keeping current certificates", "error", err)
+		return
+	}
+	slog.Info("TLS certificates reloaded", "cert_file", s.cfg.CertFile, "expires", s.cert.Leaf.NotAfter)
+}
+
+// load reads the files, recording stamp as their version on success
+func (s *store) load(stamp [3]fileStamp) error {
+	cert, err := tls.LoadX509KeyPair(s.cfg.CertFile, s.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	caPEM, err := os.ReadFile(s.cfg.CAFile)
+	if err != nil {
+		return fmt.Errorf("failed to read TLS CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("no CA certificates found in %s", s.cfg.CAFile)
+	}
+	s.cert, s.pool, s.stamp = &cert, pool, stamp
+	return nil
+}
+
+func (s *store) stamps() ([3]fileStamp, error) {
+	var stamp [3]fileStamp
+	for i, path := range []string{s.cfg.CertFile, s.cfg.KeyFile, s.cfg.CAFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return stamp, err
+		}
+		stamp[i] = fileStamp{mod: info.ModTime(), size: info.Size()}
+	}
+	return stamp, nil
+}
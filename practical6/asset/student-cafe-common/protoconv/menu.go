@@ -0,0 +1,108 @@
+package protoconv
+
+import (
+	"fmt"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	menuv2 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v2"
+)
+
+// MenuItemToV2 converts a v1 menu item
+func MenuItemToV2(item *menuv1.MenuItem) *menuv2.MenuItem {
+	if item == nil {
+		return nil
+	}
+	out := &menuv2.MenuItem{
+		Id:          item.Id,
+		Name:        item.Name,
+		Description: item.Description,
+		Price:       Money(item.Price),
+		CreatedAt:   Timestamp(item.CreatedAt),
+		UpdatedAt:   Timestamp(item.UpdatedAt),
+		OutOfStock:  item.OutOfStock,
+	}
+	for _, group := range item.ModifierGroups {
+		out.ModifierGroups = append(out.ModifierGroups, modifierGroupToV2(group))
+	}
+	for _, slot := range item.BundleSlots {
+		out.BundleSlots = append(out.BundleSlots, bundleSlotToV2(slot))
+	}
+	return out
+}
+
+// MenuItemsToV2 converts a list of v1 menu items
+func MenuItemsToV2(items []*menuv1.MenuItem) []*menuv2.MenuItem {
+	out := make([]*menuv2.MenuItem, len(items))
+	for i, item := range items {
+		out[i] = MenuItemToV2(item)
+	}
+	return out
+}
+
+func modifierGroupToV2(group *menuv1.ModifierGroup) *menuv2.ModifierGroup {
+	out := &menuv2.ModifierGroup{
+		Id:            group.Id,
+		Name:          group.Name,
+		Required:      group.Required,
+		MinSelections: group.MinSelections,
+		MaxSelections: group.MaxSelections,
+	}
+	for _, option := range group.Options {
+		out.Options = append(out.Options, &menuv2.ModifierOption{
+			Id:         option.Id,
+			Name:       option.Name,
+			PriceDelta: Money(option.PriceDelta),
+		})
+	}
+	return out
+}
+
+func bundleSlotToV2(slot *menuv1.BundleSlot) *menuv2.BundleSlot {
+	out := &menuv2.BundleSlot{Id: slot.Id, Name: slot.Name}
+	for _, choice := range slot.Choices {
+		out.Choices = append(out.Choices, &menuv2.BundleChoice{
+			MenuItemId: choice.MenuItemId,
+			Upcharge:   Money(choice.Upcharge),
+		})
+	}
+	return out
+}
+
+// CreateMenuItemRequestToV1 converts a v2 create menu item request. It
+// returns a *validation.Error naming every amount not in Currency.
+func CreateMenuItemRequestToV1(req *menuv2.CreateMenuItemRequest) (*menuv1.CreateMenuItemRequest, error) {
+	var a amounts
+	out := &menuv1.CreateMenuItemRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Price:       a.amount(req.Price, "price"),
+	}
+	for i, group := range req.ModifierGroups {
+		g := &menuv1.ModifierGroup{
+			Id:            group.Id,
+			Name:          group.Name,
+			Required:      group.Required,
+			MinSelections: group.MinSelections,
+			MaxSelections: group.MaxSelections,
+		}
+		for j, option := range group.Options {
+			g.Options = append(g.Options, &menuv1.ModifierOption{
+				Id:         option.Id,
+				Name:       option.Name,
+				PriceDelta: a.amount(option.PriceDelta, fmt.Sprintf("modifier_groups[%d].options[%d].price_delta", i, j)),
+			})
+		}
+		out.ModifierGroups = append(out.ModifierGroups, g)
+	}
+	for i, slot := range req.BundleSlots {
+		s := &menuv1.BundleSlot{Id: slot.Id, Name: slot.Name}
+		for j, choice := range slot.Choices {
+			s.Choices = append(s.Choices, &menuv1.BundleChoice{
+				MenuItemId: choice.MenuItemId,
+				Upcharge:   a.amount(choice.Upcharge, fmt.Sprintf("bundle_slots[%d].choices[%d].upcharge", i, j)),
+			})
+		}
+		out.BundleSlots = append(out.BundleSlots, s)
+	}
+	return out, a.err(req)
+}
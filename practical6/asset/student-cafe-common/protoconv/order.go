@@ -0,0 +1,149 @@
+package protoconv
+
+import (
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	orderv2 "github.com/douglasswm/student-cafe-protos/gen/go/order/v2"
+)
+
+// OrderToV2 converts a v1 order
+func OrderToV2(order *orderv1.Order) *orderv2.Order {
+	if order == nil {
+		return nil
+	}
+	out := &orderv2.Order{
+		Id:        order.Id,
+		UserId:    order.UserId,
+		Status:    order.Status,
+		CreatedAt: Timestamp(order.CreatedAt),
+		UpdatedAt: Timestamp(order.UpdatedAt),
+		Revision:  order.Revision,
+	}
+	for _, item := range order.OrderItems {
+		out.OrderItems = append(out.OrderItems, orderItemToV2(item))
+	}
+	for _, revision := range order.Revisions {
+		out.Revisions = append(out.Revisions, OrderRevisionToV2(revision))
+	}
+	return out
+}
+
+// OrdersToV2 converts a list of v1 orders
+func OrdersToV2(orders []*orderv1.Order) []*orderv2.Order {
+	out := make([]*orderv2.Order, len(orders))
+	for i, order := range orders {
+		out[i] = OrderToV2(order)
+	}
+	return out
+}
+
+func orderItemToV2(item *orderv1.OrderItem) *orderv2.OrderItem {
+	out := &orderv2.OrderItem{
+		Id:          item.Id,
+		OrderId:     item.OrderId,
+		MenuItemId:  item.MenuItemId,
+		Quantity:    item.Quantity,
+		Price:       Money(item.Price),
+		CreatedAt:   Timestamp(item.CreatedAt),
+		UpdatedAt:   Timestamp(item.UpdatedAt),
+		Name:        item.Name,
+		Description: item.Description,
+		Modifiers:   modifiersToV2(item.Modifiers),
+		LineTotal:   Money(item.LineTotal),
+	}
+	for _, component := range item.Components {
+		out.Components = append(out.Components, &orderv2.OrderItemComponent{
+			SlotId:     component.SlotId,
+			SlotName:   component.SlotName,
+			MenuItemId: component.MenuItemId,
+			Name:       component.Name,
+			Upcharge:   Money(component.Upcharge),
+			Modifiers:  modifiersToV2(component.Modifiers),
+		})
+	}
+	return out
+}
+
+func modifiersToV2(modifiers []*orderv1.OrderItemModifier) []*orderv2.OrderItemModifier {
+	var out []*orderv2.OrderItemModifier
+	for _, modifier := range modifiers {
+		out = append(out, &orderv2.OrderItemModifier{
+			ModifierOptionId: modifier.ModifierOptionId,
+			GroupName:        modifier.GroupName,
+			Name:             modifier.Name,
+			PriceDelta:       Money(modifier.PriceDelta),
+		})
+	}
+	return out
+}
+
+// OrderRevisionToV2 converts a v1 order revision
+func OrderRevisionToV2(revision *orderv1.OrderRevision) *orderv2.OrderRevision {
+	if revision == nil {
+		return nil
+	}
+	out := &orderv2.OrderRevision{
+		Id:        revision.Id,
+		OrderId:   revision.OrderId,
+		Revision:  revision.Revision,
+		CreatedAt: Timestamp(revision.CreatedAt),
+	}
+	for _, item := range revision.Items {
+		out.Items = append(out.Items, &orderv2.OrderRevisionItem{
+			MenuItemId:  item.MenuItemId,
+			Name:        item.Name,
+			OldQuantity: item.OldQuantity,
+			NewQuantity: item.NewQuantity,
+			Price:       Money(item.Price),
+			OrderItemId: item.OrderItemId,
+		})
+	}
+	return out
+}
+
+// CreateOrderRequestToV1 converts a v2 create order request
+func CreateOrderRequestToV1(req *orderv2.CreateOrderRequest) *orderv1.CreateOrderRequest {
+	out := &orderv1.CreateOrderRequest{UserId: req.UserId}
+	for _, item := range req.Items {
+		out.Items = append(out.Items, &orderv1.OrderItemRequest{
+			MenuItemId:        item.MenuItemId,
+			Quantity:          item.Quantity,
+			ModifierOptionIds: item.ModifierOptionIds,
+			BundleSelections:  bundleSelectionsToV1(item.BundleSelections),
+		})
+	}
+	return out
+}
+
+// bundleSelectionsToV1 converts v2 bundle selections
+func bundleSelectionsToV1(selections []*orderv2.BundleSelection) []*orderv1.BundleSelection {
+	var out []*orderv1.BundleSelection
+	for _, selection := range selections {
+		out = append(out, &orderv1.BundleSelection{
+			SlotId:            selection.SlotId,
+			MenuItemId:        selection.MenuItemId,
+			ModifierOptionIds: selection.ModifierOptionIds,
+		})
+	}
+	return out
+}
+
+// AmendOrderRequestToV1 converts a v2 amend order request
+func AmendOrderRequestToV1(req *orderv2.AmendOrderRequest) *orderv1.AmendOrderRequest {
+	out := &orderv1.AmendOrderRequest{Id: req.Id}
+	for _, change := range req.Changes {
+		out.Changes = append(out.Changes, &orderv1.OrderItemChange{
+			MenuItemId:        change.MenuItemId,
+			Quantity:          change.Quantity,
+			OrderItemId:       change.OrderItemId,
+			ModifierOptionIds: change.ModifierOptionIds,
+			BundleSelections:  bundleSelectionsToV1(change.BundleSelections),
+		})
+	}
+	return out
+}
+
+// ReceiptFormatToV1 converts a v2 receipt format; the two enums share
+// their numbers
+func ReceiptFormatToV1(format orderv2.ReceiptFormat) orderv1.ReceiptFormat {
+	return orderv1.ReceiptFormat(format)
+}
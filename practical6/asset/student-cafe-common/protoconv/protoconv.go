@@ -0,0 +1,79 @@
+// Package protoconv converts between the v1 and v2 cafe APIs so both can
+// be served from the same servers. v1 carries prices as float64 in an
+// implied currency and timestamps as RFC 3339 strings; v2 uses Money and
+// google.protobuf.Timestamp. Requests convert from v2 to v1 and responses
+// from v1 to v2.
+package protoconv
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/douglasswm/student-cafe-common/validation"
+	commonv2 "github.com/douglasswm/student-cafe-protos/gen/go/common/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Currency is the currency every v1 price is in
+const Currency = "USD"
+
+// minorUnits is the number of minor units in one unit of Currency
+const minorUnits = 100
+
+// Money converts a v1 price to Money, rounding to the nearest minor unit
+func Money(amount float64) *commonv2.Money {
+	return &commonv2.Money{CurrencyCode: Currency, MinorUnits: int64(math.Round(amount * minorUnits))}
+}
+
+// Amount converts m to a v1 price. A nil m is zero; m must be in Currency.
+func Amount(m *commonv2.Money) (float64, error) {
+	if m == nil {
+		return 0, nil
+	}
+	if m.CurrencyCode != Currency {
+		return 0, fmt.Errorf("currency must be %s, got %q", Currency, m.CurrencyCode)
+	}
+	return float64(m.MinorUnits) / minorUnits, nil
+}
+
+// Timestamp parses a v1 RFC 3339 timestamp. Empty or malformed strings
+// give nil, an unset Timestamp.
+func Timestamp(s string) *timestamppb.Timestamp {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// RFC3339 formats ts the way v1 does, or "" when ts is unset
+func RFC3339(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339)
+}
+
+// amounts converts the Money fields of one request, collecting every
+// field in a foreign currency as a validation violation
+type amounts struct {
+	violations []validation.Violation
+}
+
+func (a *amounts) amount(m *commonv2.Money, field string) float64 {
+	amount, err := Amount(m)
+	if err != nil {
+		a.violations = append(a.violations, validation.Violation{Field: field, Rule: "currency", Description: err.Error()})
+	}
+	return amount
+}
+
+// err returns the violations as a *validation.Error on message
+func (a *amounts) err(message protoreflect.ProtoMessage) error {
+	if len(a.violations) == 0 {
+		return nil
+	}
+	return &validation.Error{Message: message.ProtoReflect().Descriptor().FullName(), Violations: a.violations}
+}
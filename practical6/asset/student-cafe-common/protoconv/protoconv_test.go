@@ -0,0 +1,129 @@
+package protoconv
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/douglasswm/student-cafe-common/validation"
+	commonv2 "github.com/douglasswm/student-cafe-protos/gen/go/common/v2"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	menuv2 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v2"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		amount float64
+		minor  int64
+	}{
+		{4.50, 450},
+		{0, 0},
+		{0.1 + 0.2, 30},
+		{-0.25, -25},
+		{19.999, 2000},
+	}
+	for _, tt := range tests {
+		m := Money(tt.amount)
+		assert.Equal(t, "USD", m.CurrencyCode)
+		assert.Equal(t, tt.minor, m.MinorUnits, "%v", tt.amount)
+	}
+
+	amount, err := Amount(&commonv2.Money{CurrencyCode: "USD", MinorUnits: 1234})
+	require.NoError(t, err)
+	assert.Equal(t, 12.34, amount)
+
+	amount, err = Amount(nil)
+	require.NoError(t, err)
+	assert.Zero(t, amount)
+
+	_, err = Amount(&commonv2.Money{CurrencyCode: "EUR", MinorUnits: 100})
+	assert.Error(t, err)
+}
+
+func TestTimestamp(t *testing.T) {
+	created := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+	ts := Timestamp(created.Format(time.RFC3339))
+	require.NotNil(t, ts)
+	assert.True(t, created.Equal(ts.AsTime()))
+	assert.Equal(t, "2025-03-14T09:26:53Z", RFC3339(ts))
+
+	assert.Nil(t, Timestamp(""))
+	assert.Nil(t, Timestamp("yesterday"))
+	assert.Equal(t, "", RFC3339(nil))
+}
+
+func TestCreateMenuItemRequestToV1(t *testing.T) {
+	req := &menuv2.CreateMenuItemRequest{
+		Name:  "Latte",
+		Price: &commonv2.Money{CurrencyCode: "USD", MinorUnits: 450},
+		ModifierGroups: []*menuv2.ModifierGroup{{
+			Name: "Milk",
+			Options: []*menuv2.ModifierOption{
+				{Name: "Dairy"},
+				{Name: "Oat", PriceDelta: &commonv2.Money{CurrencyCode: "USD", MinorUnits: 60}},
+			},
+		}},
+	}
+	v1, err := CreateMenuItemRequestToV1(req)
+	require.NoError(t, err)
+	assert.Equal(t, 4.5, v1.Price)
+	require.Len(t, v1.ModifierGroups[0].Options, 2)
+	assert.Zero(t, v1.ModifierGroups[0].Options[0].PriceDelta)
+	assert.Equal(t, 0.6, v1.ModifierGroups[0].Options[1].PriceDelta)
+
+	// Every amount in another currency is reported
+	req.Price.CurrencyCode = "EUR"
+	req.ModifierGroups[0].Options[1].PriceDelta.CurrencyCode = "GBP"
+	_, err = CreateMenuItemRequestToV1(req)
+	var verr *validation.Error
+	require.True(t, errors.As(err, &verr))
+	require.Len(t, verr.Violations, 2)
+	assert.Equal(t, "price", verr.Violations[0].Field)
+	assert.Equal(t, "modifier_groups[0].options[1].price_delta", verr.Violations[1].Field)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestMenuItemToV2(t *testing.T) {
+	item := MenuItemToV2(&menuv1.MenuItem{
+		Id:        1,
+		Name:      "Combo",
+		Price:     7.25,
+		CreatedAt: "2025-03-14T09:26:53Z",
+		BundleSlots: []*menuv1.BundleSlot{{
+			Id:      2,
+			Name:    "Drink",
+			Choices: []*menuv1.BundleChoice{{MenuItemId: 3, Upcharge: 0.5}},
+		}},
+	})
+	assert.Equal(t, int64(725), item.Price.MinorUnits)
+	assert.Equal(t, int64(50), item.BundleSlots[0].Choices[0].Upcharge.MinorUnits)
+	assert.Equal(t, int64(1741944413), item.CreatedAt.GetSeconds())
+	assert.Nil(t, item.UpdatedAt)
+}
+
+func TestOrderToV2(t *testing.T) {
+	order := OrderToV2(&orderv1.Order{
+		Id:     1,
+		UserId: 2,
+		Status: "pending",
+		OrderItems: []*orderv1.OrderItem{{
+			MenuItemId: 3,
+			Quantity:   2,
+			Price:      2.5,
+			LineTotal:  6.2,
+			Modifiers:  []*orderv1.OrderItemModifier{{Name: "Oat", PriceDelta: 0.6}},
+		}},
+		Revisions: []*orderv1.OrderRevision{{Revision: 1, Items: []*orderv1.OrderRevisionItem{{MenuItemId: 3, NewQuantity: 2, Price: 2.5}}}},
+	})
+	item := order.OrderItems[0]
+	assert.Equal(t, int64(250), item.Price.MinorUnits)
+	assert.Equal(t, int64(620), item.LineTotal.MinorUnits)
+	assert.Equal(t, int64(60), item.Modifiers[0].PriceDelta.MinorUnits)
+	assert.Equal(t, int64(250), order.Revisions[0].Items[0].Price.MinorUnits)
+	assert.Nil(t, OrderToV2(nil))
+}
@@ -0,0 +1,35 @@
+package protoconv
+
+import (
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	userv2 "github.com/douglasswm/student-cafe-protos/gen/go/user/v2"
+)
+
+// UserToV2 converts a v1 user
+func UserToV2(u *userv1.User) *userv2.User {
+	if u == nil {
+		return nil
+	}
+	return &userv2.User{
+		Id:          u.Id,
+		Name:        u.Name,
+		Email:       u.Email,
+		IsCafeOwner: u.IsCafeOwner,
+		CreatedAt:   Timestamp(u.CreatedAt),
+		UpdatedAt:   Timestamp(u.UpdatedAt),
+	}
+}
+
+// UsersToV2 converts a list of v1 users
+func UsersToV2(users []*userv1.User) []*userv2.User {
+	out := make([]*userv2.User, len(users))
+	for i, u := range users {
+		out[i] = UserToV2(u)
+	}
+	return out
+}
+
+// CreateUserRequestToV1 converts a v2 create user request
+func CreateUserRequestToV1(req *userv2.CreateUserRequest) *userv1.CreateUserRequest {
+	return &userv1.CreateUserRequest{Name: req.Name, Email: req.Email, IsCafeOwner: req.IsCafeOwner}
+}
@@ -0,0 +1,190 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
+)
+
+// useRecorder installs a tracer provider that records spans in memory
+func useRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	_, err := Setup(context.Background(), Config{Exporter: ExporterNone})
+	require.NoError(t, err)
+	return recorder
+}
+
+type menuServer struct {
+	menuv1.UnimplementedMenuServiceServer
+	spanContext trace.SpanContext
+}
+
+func (s *menuServer) GetMenu(ctx context.Context, req *menuv1.GetMenuRequest) (*menuv1.GetMenuResponse, error) {
+	s.spanContext = trace.SpanContextFromContext(ctx)
+	return &menuv1.GetMenuResponse{}, nil
+}
+
+func TestGRPCPropagatesTrace(t *testing.T) {
+	recorder := useRecorder(t)
+
+	listener := bufconn.Listen(1024 * 1024)
+	backend := &menuServer{}
+	server := grpc.NewServer(ServerOption())
+	menuv1.RegisterMenuServiceServer(server, backend)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		DialOption())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "POST /api/orders")
+	_, err = menuv1.NewMenuServiceClient(conn).GetMenu(ctx, &menuv1.GetMenuRequest{})
+	require.NoError(t, err)
+	parent.End()
+
+	// The server continues the caller's trace
+	assert.Equal(t, parent.SpanContext().TraceID(), backend.spanContext.TraceID())
+
+	spans := map[trace.SpanKind]sdktrace.ReadOnlySpan{}
+	for _, s := range recorder.Ended() {
+		spans[s.SpanKind()] = s
+	}
+	require.Contains(t, spans, trace.SpanKindClient)
+	require.Contains(t, spans, trace.SpanKindServer)
+	assert.Equal(t, "menu.v1.MenuService/GetMenu", spans[trace.SpanKindClient].Name())
+	assert.Equal(t, spans[trace.SpanKindClient].SpanContext().SpanID(), spans[trace.SpanKindServer].Parent().SpanID())
+}
+
+type widget struct {
+	gorm.Model
+	Name string
+}
+
+func TestGORMPlugin(t *testing.T) {
+	recorder := useRecorder(t)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(GORMPlugin{}))
+	require.NoError(t, db.AutoMigrate(&widget{}))
+
+	// Queries outside a traced request are not recorded
+	require.Empty(t, recorder.Ended())
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "CreateOrder")
+	require.NoError(t, db.WithContext(ctx).Create(&widget{Name: "sprocket"}).Error)
+	var found widget
+	require.NoError(t, db.WithContext(ctx).First(&found, "name = ?", "sprocket").Error)
+	err = db.WithContext(ctx).First(&found, 999).Error
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	parent.End()
+
+	var dbSpans []sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.SpanKind() == trace.SpanKindClient {
+			dbSpans = append(dbSpans, s)
+		}
+	}
+	require.Len(t, dbSpans, 3)
+	assert.Equal(t, "gorm.create", dbSpans[0].Name())
+	assert.Equal(t, "gorm.query", dbSpans[1].Name())
+	for _, s := range dbSpans {
+		assert.Equal(t, parent.SpanContext().SpanID(), s.Parent().SpanID())
+	}
+
+	attrs := map[string]string{}
+	for _, kv := range dbSpans[1].Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	assert.Equal(t, "sqlite", attrs["db.system"])
+	assert.Equal(t, "widgets", attrs["db.sql.table"])
+	assert.Contains(t, attrs["db.statement"], "SELECT")
+
+	// A missing record is not reported as an error
+	assert.NotEqual(t, "Error", dbSpans[2].Status().Code.String())
+}
+
+func TestSetupFileExporter(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := Setup(context.Background(), Config{ServiceName: "menu-service", Exporter: ExporterFile, FilePath: path})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "GetMenu")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	require.True(t, scanner.Scan())
+
+	var exported struct {
+		Name     string
+		Resource []struct {
+			Key   string
+			Value struct{ Value interface{} }
+		}
+	}
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &exported))
+	assert.Equal(t, "GetMenu", exported.Name)
+	var serviceName interface{}
+	for _, kv := range exported.Resource {
+		if kv.Key == "service.name" {
+			serviceName = kv.Value.Value
+		}
+	}
+	assert.Equal(t, "menu-service", serviceName)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_TRACES_FILE", "")
+
+	cfg := ConfigFromEnv("order-service")
+	assert.Equal(t, ExporterNone, cfg.Exporter)
+	assert.Equal(t, "order-service-traces.jsonl", cfg.FilePath)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4317")
+	assert.Equal(t, ExporterOTLP, ConfigFromEnv("order-service").Exporter)
+
+	t.Setenv("OTEL_TRACES_EXPORTER", ExporterStdout)
+	assert.Equal(t, ExporterStdout, ConfigFromEnv("order-service").Exporter)
+
+	_, err := Setup(context.Background(), Config{Exporter: "zipkin"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package validation
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerInterceptor rejects requests that break their rules with
+// InvalidArgument before they reach the handler
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if m, ok := req.(proto.Message); ok {
+			if err := Validate(m); err != nil {
+				return nil, statusFor(err)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ServerOption installs UnaryServerInterceptor on a gRPC server
+func ServerOption() grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(UnaryServerInterceptor())
+}
+
+func statusFor(err error) error {
+	if verr, ok := err.(*Error); ok {
+		return verr.GRPCStatus().Err()
+	}
+	return status.Errorf(codes.Internal, "%v", err)
+}
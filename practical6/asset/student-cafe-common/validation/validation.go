@@ -0,0 +1,78 @@
+// Package validation enforces the protovalidate (buf.validate) rules
+// declared on the request messages in student-cafe-protos, using
+// protovalidate-go, and reports violations as gRPC InvalidArgument errors.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Violation is a field that breaks one of its rules
+type Violation struct {
+	// Field is the path to the field, e.g. "items[0].quantity"
+	Field string
+	// Rule is the broken rule, e.g. "int32.gt"
+	Rule        string
+	Description string
+}
+
+// Error lists every rule a message breaks
+type Error struct {
+	Message    protoreflect.FullName
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + ": " + v.Description
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Message.Name(), strings.Join(parts, "; "))
+}
+
+// GRPCStatus reports the violations as InvalidArgument with BadRequest
+// details, one field violation each
+func (e *Error) GRPCStatus() *status.Status {
+	br := &errdetails.BadRequest{}
+	for _, v := range e.Violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	st := status.New(codes.InvalidArgument, e.Error())
+	if withDetails, err := st.WithDetails(br); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// Validate checks m and the messages nested in it against their rules. It
+// returns an *Error listing the violations, or another error when a rule
+// cannot be evaluated.
+func Validate(m proto.Message) error {
+	err := protovalidate.Validate(m)
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+
+	violations := make([]Violation, len(verr.Violations))
+	for i, v := range verr.Violations {
+		violations[i] = Violation{
+			Field:       protovalidate.FieldPathString(v.Proto.GetField()),
+			Rule:        v.Proto.GetRuleId(),
+			Description: v.Proto.GetMessage(),
+		}
+	}
+	return &Error{Message: m.ProtoReflect().Descriptor().FullName(), Violations: violations}
+}
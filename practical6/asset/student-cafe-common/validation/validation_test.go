@@ -0,0 +1,154 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fields returns the path of every violation in err
+func fields(t *testing.T, err error) []string {
+	t.Helper()
+	var verr *Error
+	require.True(t, errors.As(err, &verr), "expected *Error, got %v", err)
+	var paths []string
+	for _, v := range verr.Violations {
+		paths = append(paths, v.Field)
+	}
+	return paths
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		message proto.Message
+		want    []string
+	}{
+		{
+			name:    "valid user",
+			message: &userv1.CreateUserRequest{Name: "Ada", Email: "ada@example.com"},
+		},
+		{
+			name:    "empty user",
+			message: &userv1.CreateUserRequest{},
+			want:    []string{"name", "email"},
+		},
+		{
+			name:    "email with a display name",
+			message: &userv1.CreateUserRequest{Name: "Ada", Email: "Ada <ada@example.com>"},
+			want:    []string{"email"},
+		},
+		{
+			name:    "email without an @",
+			message: &userv1.CreateUserRequest{Name: "Ada", Email: "ada.example.com"},
+			want:    []string{"email"},
+		},
+		{
+			name:    "free menu item",
+			message: &menuv1.CreateMenuItemRequest{Name: "Water", Price: 0},
+		},
+		{
+			name:    "negative price",
+			message: &menuv1.CreateMenuItemRequest{Name: "Coffee", Price: -1},
+			want:    []string{"price"},
+		},
+		{
+			name: "nested modifier group",
+			message: &menuv1.CreateMenuItemRequest{Name: "Coffee", Price: 3, ModifierGroups: []*menuv1.ModifierGroup{
+				{Name: "Milk", Options: []*menuv1.ModifierOption{{Name: "Oat", PriceDelta: 0.5}}},
+				{Name: "", MinSelections: -1},
+			}},
+			want: []string{"modifier_groups[1].name", "modifier_groups[1].min_selections", "modifier_groups[1].options"},
+		},
+		{
+			name:    "valid order",
+			message: &orderv1.CreateOrderRequest{UserId: 1, Items: []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 2}}},
+		},
+		{
+			name:    "empty order",
+			message: &orderv1.CreateOrderRequest{UserId: 1},
+			want:    []string{"items"},
+		},
+		{
+			name: "bad quantities",
+			message: &orderv1.CreateOrderRequest{Items: []*orderv1.OrderItemRequest{
+				{MenuItemId: 1, Quantity: 1},
+				{MenuItemId: 2, Quantity: 0},
+				{MenuItemId: 3, Quantity: -4},
+			}},
+			want: []string{"user_id", "items[1].quantity", "items[2].quantity"},
+		},
+		{
+			name:    "amendment may remove items",
+			message: &orderv1.AmendOrderRequest{Id: 1, Changes: []*orderv1.OrderItemChange{{MenuItemId: 1, Quantity: 0}}},
+		},
+		{
+			name:    "undefined receipt format",
+			message: &orderv1.GetOrderReceiptRequest{Id: 1, Format: orderv1.ReceiptFormat(42)},
+			want:    []string{"format"},
+		},
+		{
+			name:    "messages without rules",
+			message: &menuv1.GetMenuRequest{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.message)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.want, fields(t, err))
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	err := Validate(&orderv1.CreateOrderRequest{UserId: 1, Items: []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 0}}})
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "invalid CreateOrderRequest: items[0].quantity: value must be greater than 0", st.Message())
+	require.Len(t, st.Details(), 1)
+	br, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, br.FieldViolations, 1)
+	assert.Equal(t, "items[0].quantity", br.FieldViolations[0].Field)
+	assert.Equal(t, "value must be greater than 0", br.FieldViolations[0].Description)
+
+	var verr *Error
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "int32.gt", verr.Violations[0].Rule)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.v1.UserService/CreateUser"}
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return &userv1.CreateUserResponse{}, nil
+	}
+
+	_, err := interceptor(context.Background(), &userv1.CreateUserRequest{Name: "Ada", Email: "not-an-email"}, info, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.False(t, called, "invalid requests must not reach the handler")
+
+	_, err = interceptor(context.Background(), &userv1.CreateUserRequest{Name: "Ada", Email: "ada@example.com"}, info, handler)
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
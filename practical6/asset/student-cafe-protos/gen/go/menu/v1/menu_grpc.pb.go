@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: menu/v1/menu.proto
 
 package menuv1
 
@@ -14,6 +18,14 @@ import (
 // Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
+const (
+	MenuService_GetMenuItem_FullMethodName      = "/menu.v1.MenuService/GetMenuItem"
+	MenuService_GetMenu_FullMethodName          = "/menu.v1.MenuService/GetMenu"
+	MenuService_CreateMenuItem_FullMethodName   = "/menu.v1.MenuService/CreateMenuItem"
+	MenuService_GetMenuVersion_FullMethodName   = "/menu.v1.MenuService/GetMenuVersion"
+	MenuService_SetMenuItemStock_FullMethodName = "/menu.v1.MenuService/SetMenuItemStock"
+)
+
 // MenuServiceClient is the client API for MenuService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
@@ -24,6 +36,10 @@ type MenuServiceClient interface {
 	GetMenu(ctx context.Context, in *GetMenuRequest, opts ...grpc.CallOption) (*GetMenuResponse, error)
 	// Create a new menu item
 	CreateMenuItem(ctx context.Context, in *CreateMenuItemRequest, opts ...grpc.CallOption) (*CreateMenuItemResponse, error)
+	// Get the menu's current version, which changes whenever the menu does
+	GetMenuVersion(ctx context.Context, in *GetMenuVersionRequest, opts ...grpc.CallOption) (*GetMenuVersionResponse, error)
+	// Mark a menu item as in or out of stock
+	SetMenuItemStock(ctx context.Context, in *SetMenuItemStockRequest, opts ...grpc.CallOption) (*SetMenuItemStockResponse, error)
 }
 
 type menuServiceClient struct {
@@ -36,7 +52,7 @@ func NewMenuServiceClient(cc grpc.ClientConnInterface) MenuServiceClient {
 
 func (c *menuServiceClient) GetMenuItem(ctx context.Context, in *GetMenuItemRequest, opts ...grpc.CallOption) (*GetMenuItemResponse, error) {
 	out := new(GetMenuItemResponse)
-	err := c.cc.Invoke(ctx, "/menu.v1.MenuService/GetMenuItem", in, out, opts...)
+	err := c.cc.Invoke(ctx, MenuService_GetMenuItem_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +61,7 @@ func (c *menuServiceClient) GetMenuItem(ctx context.Context, in *GetMenuItemRequ
 
 func (c *menuServiceClient) GetMenu(ctx context.Context, in *GetMenuRequest, opts ...grpc.CallOption) (*GetMenuResponse, error) {
 	out := new(GetMenuResponse)
-	err := c.cc.Invoke(ctx, "/menu.v1.MenuService/GetMenu", in, out, opts...)
+	err := c.cc.Invoke(ctx, MenuService_GetMenu_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +70,25 @@ func (c *menuServiceClient) GetMenu(ctx context.Context, in *GetMenuRequest, opt
 
 func (c *menuServiceClient) CreateMenuItem(ctx context.Context, in *CreateMenuItemRequest, opts ...grpc.CallOption) (*CreateMenuItemResponse, error) {
 	out := new(CreateMenuItemResponse)
-	err := c.cc.Invoke(ctx, "/menu.v1.MenuService/CreateMenuItem", in, out, opts...)
+	err := c.cc.Invoke(ctx, MenuService_CreateMenuItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) GetMenuVersion(ctx context.Context, in *GetMenuVersionRequest, opts ...grpc.CallOption) (*GetMenuVersionResponse, error) {
+	out := new(GetMenuVersionResponse)
+	err := c.cc.Invoke(ctx, MenuService_GetMenuVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) SetMenuItemStock(ctx context.Context, in *SetMenuItemStockRequest, opts ...grpc.CallOption) (*SetMenuItemStockResponse, error) {
+	out := new(SetMenuItemStockResponse)
+	err := c.cc.Invoke(ctx, MenuService_SetMenuItemStock_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +105,10 @@ type MenuServiceServer interface {
 	GetMenu(context.Context, *GetMenuRequest) (*GetMenuResponse, error)
 	// Create a new menu item
 	CreateMenuItem(context.Context, *CreateMenuItemRequest) (*CreateMenuItemResponse, error)
+	// Get the menu's current version, which changes whenever the menu does
+	GetMenuVersion(context.Context, *GetMenuVersionRequest) (*GetMenuVersionResponse, error)
+	// Mark a menu item as in or out of stock
+	SetMenuItemStock(context.Context, *SetMenuItemStockRequest) (*SetMenuItemStockResponse, error)
 	mustEmbedUnimplementedMenuServiceServer()
 }
 
@@ -87,6 +125,12 @@ func (UnimplementedMenuServiceServer) GetMenu(context.Context, *GetMenuRequest)
 func (UnimplementedMenuServiceServer) CreateMenuItem(context.Context, *CreateMenuItemRequest) (*CreateMenuItemResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateMenuItem not implemented")
 }
+func (UnimplementedMenuServiceServer) GetMenuVersion(context.Context, *GetMenuVersionRequest) (*GetMenuVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMenuVersion not implemented")
+}
+func (UnimplementedMenuServiceServer) SetMenuItemStock(context.Context, *SetMenuItemStockRequest) (*SetMenuItemStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMenuItemStock not implemented")
+}
 func (UnimplementedMenuServiceServer) mustEmbedUnimplementedMenuServiceServer() {}
 
 // UnsafeMenuServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -110,7 +154,7 @@ func _MenuService_GetMenuItem_Handler(srv interface{}, ctx context.Context, dec
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/menu.v1.MenuService/GetMenuItem",
+		FullMethod: MenuService_GetMenuItem_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MenuServiceServer).GetMenuItem(ctx, req.(*GetMenuItemRequest))
@@ -128,7 +172,7 @@ func _MenuService_GetMenu_Handler(srv interface{}, ctx context.Context, dec func
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/menu.v1.MenuService/GetMenu",
+		FullMethod: MenuService_GetMenu_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MenuServiceServer).GetMenu(ctx, req.(*GetMenuRequest))
@@ -146,7 +190,7 @@ func _MenuService_CreateMenuItem_Handler(srv interface{}, ctx context.Context, d
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/menu.v1.MenuService/CreateMenuItem",
+		FullMethod: MenuService_CreateMenuItem_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MenuServiceServer).CreateMenuItem(ctx, req.(*CreateMenuItemRequest))
@@ -154,6 +198,42 @@ func _MenuService_CreateMenuItem_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MenuService_GetMenuVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMenuVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).GetMenuVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_GetMenuVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).GetMenuVersion(ctx, req.(*GetMenuVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_SetMenuItemStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMenuItemStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).SetMenuItemStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_SetMenuItemStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).SetMenuItemStock(ctx, req.(*SetMenuItemStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MenuService_ServiceDesc is the grpc.ServiceDesc for MenuService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -173,6 +253,14 @@ var MenuService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateMenuItem",
 			Handler:    _MenuService_CreateMenuItem_Handler,
 		},
+		{
+			MethodName: "GetMenuVersion",
+			Handler:    _MenuService_GetMenuVersion_Handler,
+		},
+		{
+			MethodName: "SetMenuItemStock",
+			Handler:    _MenuService_SetMenuItemStock_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "menu/v1/menu.proto",
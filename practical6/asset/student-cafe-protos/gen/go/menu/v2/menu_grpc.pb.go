@@ -0,0 +1,267 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: menu/v2/menu.proto
+
+package menuv2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MenuService_GetMenuItem_FullMethodName      = "/menu.v2.MenuService/GetMenuItem"
+	MenuService_GetMenu_FullMethodName          = "/menu.v2.MenuService/GetMenu"
+	MenuService_CreateMenuItem_FullMethodName   = "/menu.v2.MenuService/CreateMenuItem"
+	MenuService_GetMenuVersion_FullMethodName   = "/menu.v2.MenuService/GetMenuVersion"
+	MenuService_SetMenuItemStock_FullMethodName = "/menu.v2.MenuService/SetMenuItemStock"
+)
+
+// MenuServiceClient is the client API for MenuService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MenuServiceClient interface {
+	// Get a menu item by ID
+	GetMenuItem(ctx context.Context, in *GetMenuItemRequest, opts ...grpc.CallOption) (*GetMenuItemResponse, error)
+	// Get all menu items
+	GetMenu(ctx context.Context, in *GetMenuRequest, opts ...grpc.CallOption) (*GetMenuResponse, error)
+	// Create a new menu item
+	CreateMenuItem(ctx context.Context, in *CreateMenuItemRequest, opts ...grpc.CallOption) (*CreateMenuItemResponse, error)
+	// Get the menu's current version, which changes whenever the menu does
+	GetMenuVersion(ctx context.Context, in *GetMenuVersionRequest, opts ...grpc.CallOption) (*GetMenuVersionResponse, error)
+	// Mark a menu item as in or out of stock
+	SetMenuItemStock(ctx context.Context, in *SetMenuItemStockRequest, opts ...grpc.CallOption) (*SetMenuItemStockResponse, error)
+}
+
+type menuServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMenuServiceClient(cc grpc.ClientConnInterface) MenuServiceClient {
+	return &menuServiceClient{cc}
+}
+
+func (c *menuServiceClient) GetMenuItem(ctx context.Context, in *GetMenuItemRequest, opts ...grpc.CallOption) (*GetMenuItemResponse, error) {
+	out := new(GetMenuItemResponse)
+	err := c.cc.Invoke(ctx, MenuService_GetMenuItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) GetMenu(ctx context.Context, in *GetMenuRequest, opts ...grpc.CallOption) (*GetMenuResponse, error) {
+	out := new(GetMenuResponse)
+	err := c.cc.Invoke(ctx, MenuService_GetMenu_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) CreateMenuItem(ctx context.Context, in *CreateMenuItemRequest, opts ...grpc.CallOption) (*CreateMenuItemResponse, error) {
+	out := new(CreateMenuItemResponse)
+	err := c.cc.Invoke(ctx, MenuService_CreateMenuItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) GetMenuVersion(ctx context.Context, in *GetMenuVersionRequest, opts ...grpc.CallOption) (*GetMenuVersionResponse, error) {
+	out := new(GetMenuVersionResponse)
+	err := c.cc.Invoke(ctx, MenuService_GetMenuVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *menuServiceClient) SetMenuItemStock(ctx context.Context, in *SetMenuItemStockRequest, opts ...grpc.CallOption) (*SetMenuItemStockResponse, error) {
+	out := new(SetMenuItemStockResponse)
+	err := c.cc.Invoke(ctx, MenuService_SetMenuItemStock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MenuServiceServer is the server API for MenuService service.
+// All implementations must embed UnimplementedMenuServiceServer
+// for forward compatibility
+type MenuServiceServer interface {
+	// Get a menu item by ID
+	GetMenuItem(context.Context, *GetMenuItemRequest) (*GetMenuItemResponse, error)
+	// Get all menu items
+	GetMenu(context.Context, *GetMenuRequest) (*GetMenuResponse, error)
+	// Create a new menu item
+	CreateMenuItem(context.Context, *CreateMenuItemRequest) (*CreateMenuItemResponse, error)
+	// Get the menu's current version, which changes whenever the menu does
+	GetMenuVersion(context.Context, *GetMenuVersionRequest) (*GetMenuVersionResponse, error)
+	// Mark a menu item as in or out of stock
+	SetMenuItemStock(context.Context, *SetMenuItemStockRequest) (*SetMenuItemStockResponse, error)
+	mustEmbedUnimplementedMenuServiceServer()
+}
+
+// UnimplementedMenuServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMenuServiceServer struct {
+}
+
+func (UnimplementedMenuServiceServer) GetMenuItem(context.Context, *GetMenuItemRequest) (*GetMenuItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMenuItem not implemented")
+}
+func (UnimplementedMenuServiceServer) GetMenu(context.Context, *GetMenuRequest) (*GetMenuResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMenu not implemented")
+}
+func (UnimplementedMenuServiceServer) CreateMenuItem(context.Context, *CreateMenuItemRequest) (*CreateMenuItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMenuItem not implemented")
+}
+func (UnimplementedMenuServiceServer) GetMenuVersion(context.Context, *GetMenuVersionRequest) (*GetMenuVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMenuVersion not implemented")
+}
+func (UnimplementedMenuServiceServer) SetMenuItemStock(context.Context, *SetMenuItemStockRequest) (*SetMenuItemStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMenuItemStock not implemented")
+}
+func (UnimplementedMenuServiceServer) mustEmbedUnimplementedMenuServiceServer() {}
+
+// UnsafeMenuServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MenuServiceServer will
+// result in compilation errors.
+type UnsafeMenuServiceServer interface {
+	mustEmbedUnimplementedMenuServiceServer()
+}
+
+func RegisterMenuServiceServer(s grpc.ServiceRegistrar, srv MenuServiceServer) {
+	s.RegisterService(&MenuService_ServiceDesc, srv)
+}
+
+func _MenuService_GetMenuItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMenuItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).GetMenuItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_GetMenuItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).GetMenuItem(ctx, req.(*GetMenuItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_GetMenu_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMenuRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).GetMenu(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_GetMenu_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).GetMenu(ctx, req.(*GetMenuRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_CreateMenuItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMenuItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).CreateMenuItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_CreateMenuItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).CreateMenuItem(ctx, req.(*CreateMenuItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_GetMenuVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMenuVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).GetMenuVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_GetMenuVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).GetMenuVersion(ctx, req.(*GetMenuVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MenuService_SetMenuItemStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMenuItemStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MenuServiceServer).SetMenuItemStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MenuService_SetMenuItemStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MenuServiceServer).SetMenuItemStock(ctx, req.(*SetMenuItemStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MenuService_ServiceDesc is the grpc.ServiceDesc for MenuService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MenuService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "menu.v2.MenuService",
+	HandlerType: (*MenuServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMenuItem",
+			Handler:    _MenuService_GetMenuItem_Handler,
+		},
+		{
+			MethodName: "GetMenu",
+			Handler:    _MenuService_GetMenu_Handler,
+		},
+		{
+			MethodName: "CreateMenuItem",
+			Handler:    _MenuService_CreateMenuItem_Handler,
+		},
+		{
+			MethodName: "GetMenuVersion",
+			Handler:    _MenuService_GetMenuVersion_Handler,
+		},
+		{
+			MethodName: "SetMenuItemStock",
+			Handler:    _MenuService_SetMenuItemStock_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "menu/v2/menu.proto",
+}
@@ -2,17 +2,35 @@ package integration
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
-
+	"time"
+
+	"github.com/douglasswm/student-cafe-common/discovery"
+	"github.com/douglasswm/student-cafe-common/grpcclient"
+	"github.com/douglasswm/student-cafe-common/health"
+	"github.com/douglasswm/student-cafe-common/lifecycle"
+	"github.com/douglasswm/student-cafe-common/validation"
+	commonv2 "github.com/douglasswm/student-cafe-protos/gen/go/common/v2"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	menuv2 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v2"
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	orderv2 "github.com/douglasswm/student-cafe-protos/gen/go/order/v2"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	userv2 "github.com/douglasswm/student-cafe-protos/gen/go/user/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
 	// Import actual service implementations
@@ -20,6 +38,7 @@ import (
 	menugrpc "menu-service/grpc"
 	menumodels "menu-service/models"
 
+	"order-service/backfill"
 	orderdatabase "order-service/database"
 	ordergrpc "order-service/grpc"
 	ordermodels "order-service/models"
@@ -56,8 +75,10 @@ func setupUserService(t *testing.T) {
 
 	// Create gRPC server with bufconn
 	userListener = bufconn.Listen(bufSize)
-	s := grpc.NewServer()
-	userv1.RegisterUserServiceServer(s, usergrpc.NewUserServer())
+	s := grpc.NewServer(validation.ServerOption())
+	userServer := usergrpc.NewUserServer()
+	userv1.RegisterUserServiceServer(s, userServer)
+	userv2.RegisterUserServiceServer(s, usergrpc.NewUserServerV2(userServer))
 
 	go func() {
 		_ = s.Serve(userListener)
@@ -72,33 +93,40 @@ func setupMenuService(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&menumodels.MenuItem{})
+	err = db.AutoMigrate(&menumodels.MenuItem{}, &menumodels.ModifierGroup{}, &menumodels.ModifierOption{}, &menumodels.BundleSlot{}, &menumodels.BundleChoice{})
 	require.NoError(t, err)
 
 	menudatabase.DB = db
 
 	// Create gRPC server with bufconn
 	menuListener = bufconn.Listen(bufSize)
-	s := grpc.NewServer()
-	menuv1.RegisterMenuServiceServer(s, menugrpc.NewMenuServer())
+	s := grpc.NewServer(validation.ServerOption())
+	menuServer := menugrpc.NewMenuServer()
+	menuv1.RegisterMenuServiceServer(s, menuServer)
+	menuv2.RegisterMenuServiceServer(s, menugrpc.NewMenuServerV2(menuServer))
 
 	go func() {
 		_ = s.Serve(menuListener)
 	}()
 }
 
-// setupOrderService creates and starts the order service with clients to user and menu services
-func setupOrderService(t *testing.T, userConn, menuConn *grpc.ClientConn) {
-	// Setup in-memory database
+// setupOrderDB creates the order service's in-memory database
+func setupOrderDB(t *testing.T) {
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
 	})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&ordermodels.Order{}, &ordermodels.OrderItem{})
+	err = db.AutoMigrate(&ordermodels.Order{}, &ordermodels.OrderItem{}, &ordermodels.OrderItemModifier{}, &ordermodels.OrderItemComponent{}, &ordermodels.OrderRevision{}, &ordermodels.OrderRevisionItem{})
 	require.NoError(t, err)
 
 	orderdatabase.DB = db
+}
+
+// setupOrderService creates and starts the order service with clients to user and menu services
+func setupOrderService(t *testing.T, userConn, menuConn *grpc.ClientConn) {
+	// Setup in-memory database
+	setupOrderDB(t)
 
 	// Create order server with injected clients
 	orderServer := &ordergrpc.OrderServer{
@@ -108,8 +136,9 @@ func setupOrderService(t *testing.T, userConn, menuConn *grpc.ClientConn) {
 
 	// Create gRPC server with bufconn
 	orderListener = bufconn.Listen(bufSize)
-	s := grpc.NewServer()
+	s := grpc.NewServer(validation.ServerOption())
 	orderv1.RegisterOrderServiceServer(s, orderServer)
+	orderv2.RegisterOrderServiceServer(s, ordergrpc.NewOrderServerV2(orderServer))
 
 	go func() {
 		_ = s.Serve(orderListener)
@@ -187,6 +216,133 @@ func TestIntegration_CreateAndGetMenuItem(t *testing.T) {
 	assert.InDelta(t, 3.50, getResp.MenuItem.Price, 0.001)
 }
 
+func TestIntegration_MenuVersionChangesWithMenu(t *testing.T) {
+	setupMenuService(t)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := menuv1.NewMenuServiceClient(conn)
+
+	before, err := client.GetMenuVersion(ctx, &menuv1.GetMenuVersionRequest{})
+	require.NoError(t, err)
+	unchanged, err := client.GetMenuVersion(ctx, &menuv1.GetMenuVersionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, before.Version, unchanged.Version)
+
+	_, err = client.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Versioned Tea", Price: 2.00, ModifierGroups: []*menuv1.ModifierGroup{
+		{Name: "Milk", Options: []*menuv1.ModifierOption{{Name: "Oat milk", PriceDelta: 0.50}, {Name: "Soy milk", PriceDelta: 0.40}}},
+	}})
+	require.NoError(t, err)
+
+	after, err := client.GetMenuVersion(ctx, &menuv1.GetMenuVersionRequest{})
+	require.NoError(t, err)
+	assert.NotEqual(t, before.Version, after.Version)
+
+	// Changes to an item's options alone also change the version
+	var option menumodels.ModifierOption
+	require.NoError(t, menudatabase.DB.Where("name = ?", "Oat milk").Last(&option).Error)
+	require.NoError(t, menudatabase.DB.Model(&option).Update("price_delta", 0.60).Error)
+	repriced, err := client.GetMenuVersion(ctx, &menuv1.GetMenuVersionRequest{})
+	require.NoError(t, err)
+	assert.NotEqual(t, after.Version, repriced.Version)
+
+	require.NoError(t, menudatabase.DB.Delete(&option).Error)
+	removed, err := client.GetMenuVersion(ctx, &menuv1.GetMenuVersionRequest{})
+	require.NoError(t, err)
+	assert.NotEqual(t, repriced.Version, removed.Version)
+}
+
+func TestIntegration_V1AndV2SideBySide(t *testing.T) {
+	setupUserService(t)
+	setupMenuService(t)
+
+	ctx := context.Background()
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	setupOrderService(t, userConn, menuConn)
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	// A v1 client creates the user, a v2 client the menu item
+	user, err := userv1.NewUserServiceClient(userConn).CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "Migrating User",
+		Email: "migrating@test.com",
+	})
+	require.NoError(t, err)
+
+	menuV2 := menuv2.NewMenuServiceClient(menuConn)
+	item, err := menuV2.CreateMenuItem(ctx, &menuv2.CreateMenuItemRequest{
+		Name:  "Flat White",
+		Price: &commonv2.Money{CurrencyCode: "USD", MinorUnits: 375},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(375), item.MenuItem.Price.MinorUnits)
+	require.NotNil(t, item.MenuItem.CreatedAt)
+	assert.WithinDuration(t, time.Now(), item.MenuItem.CreatedAt.AsTime(), time.Minute)
+
+	// Both versions read the same item
+	itemV1, err := menuv1.NewMenuServiceClient(menuConn).GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: item.MenuItem.Id})
+	require.NoError(t, err)
+	assert.Equal(t, 3.75, itemV1.MenuItem.Price)
+
+	userV2, err := userv2.NewUserServiceClient(userConn).GetUser(ctx, &userv2.GetUserRequest{Id: user.User.Id})
+	require.NoError(t, err)
+	assert.Equal(t, "migrating@test.com", userV2.User.Email)
+	assert.NotNil(t, userV2.User.CreatedAt)
+
+	order, err := orderv2.NewOrderServiceClient(orderConn).CreateOrder(ctx, &orderv2.CreateOrderRequest{
+		UserId: user.User.Id,
+		Items:  []*orderv2.OrderItemRequest{{MenuItemId: item.MenuItem.Id, Quantity: 2}},
+	})
+	require.NoError(t, err)
+	require.Len(t, order.Order.OrderItems, 1)
+	assert.Equal(t, int64(750), order.Order.OrderItems[0].LineTotal.MinorUnits)
+
+	orderV1, err := orderv1.NewOrderServiceClient(orderConn).GetOrder(ctx, &orderv1.GetOrderRequest{Id: order.Order.Id})
+	require.NoError(t, err)
+	assert.Equal(t, 7.5, orderV1.Order.OrderItems[0].LineTotal)
+
+	t.Run("v1 rules apply to v2 amounts", func(t *testing.T) {
+		_, err := menuV2.CreateMenuItem(ctx, &menuv2.CreateMenuItemRequest{
+			Name:  "Refund",
+			Price: &commonv2.Money{CurrencyCode: "USD", MinorUnits: -100},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("other currencies are rejected", func(t *testing.T) {
+		_, err := menuV2.CreateMenuItem(ctx, &menuv2.CreateMenuItemRequest{
+			Name:  "Import",
+			Price: &commonv2.Money{CurrencyCode: "EUR", MinorUnits: 100},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("price is required", func(t *testing.T) {
+		_, err := menuV2.CreateMenuItem(ctx, &menuv2.CreateMenuItemRequest{Name: "Mystery"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
 func TestIntegration_CompleteOrderFlow(t *testing.T) {
 	// Setup all three services
 	setupUserService(t)
@@ -341,6 +497,63 @@ func TestIntegration_OrderValidation(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "menu item 9999 not found")
 	})
+
+	// Requests breaking the proto rules are rejected before the handler runs
+	t.Run("zero quantity", func(t *testing.T) {
+		_, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items: []*orderv1.OrderItemRequest{
+				{MenuItemId: 1, Quantity: 0},
+			},
+		})
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		require.Len(t, st.Details(), 1)
+		br, ok := st.Details()[0].(*errdetails.BadRequest)
+		require.True(t, ok)
+		require.Len(t, br.FieldViolations, 1)
+		assert.Equal(t, "items[0].quantity", br.FieldViolations[0].Field)
+	})
+
+	t.Run("no items", func(t *testing.T) {
+		_, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{UserId: userResp.User.Id})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		_, err := userClient.CreateUser(ctx, &userv1.CreateUserRequest{Name: "", Email: "not-an-email"})
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Contains(t, st.Message(), "name: value length must be at least 1 characters")
+		assert.Contains(t, st.Message(), "email: value must be a valid email address")
+	})
+
+	t.Run("negative price", func(t *testing.T) {
+		_, err := menuv1.NewMenuServiceClient(menuConn).CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Coffee", Price: -2.5})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("out of stock", func(t *testing.T) {
+		menuClient := menuv1.NewMenuServiceClient(menuConn)
+		item, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Scone", Price: 2.00})
+		require.NoError(t, err)
+		order := &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: item.MenuItem.Id, Quantity: 1}},
+		}
+
+		stock, err := menuClient.SetMenuItemStock(ctx, &menuv1.SetMenuItemStockRequest{Id: item.MenuItem.Id, OutOfStock: true})
+		require.NoError(t, err)
+		assert.True(t, stock.MenuItem.OutOfStock)
+		_, err = orderClient.CreateOrder(ctx, order)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = menuClient.SetMenuItemStock(ctx, &menuv1.SetMenuItemStockRequest{Id: item.MenuItem.Id})
+		require.NoError(t, err)
+		_, err = orderClient.CreateOrder(ctx, order)
+		assert.NoError(t, err)
+	})
 }
 
 func TestIntegration_ConcurrentOrders(t *testing.T) {
@@ -428,3 +641,816 @@ func TestIntegration_ConcurrentOrders(t *testing.T) {
 	assert.GreaterOrEqual(t, successCount, numOrders/2,
 		"At least half of concurrent orders should succeed (SQLite has known locking limitations)")
 }
+
+func TestIntegration_BackfillOrderItemNames(t *testing.T) {
+	setupMenuService(t)
+
+	ctx := context.Background()
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+
+	item, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name:        "Backfill Muffin",
+		Description: "Blueberry muffin",
+		Price:       3.00,
+	})
+	require.NoError(t, err)
+
+	// Order database with rows created before names were snapshotted
+	db, err := gorm.Open(sqlite.Open("file:backfill?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&ordermodels.Order{}, &ordermodels.OrderItem{}))
+
+	legacy := ordermodels.Order{
+		UserID: 1,
+		Status: "completed",
+		OrderItems: []ordermodels.OrderItem{
+			{MenuItemID: uint(item.MenuItem.Id), Quantity: 1, Price: 2.75},
+			{MenuItemID: 9999, Quantity: 1, Price: 1.00},
+		},
+	}
+	require.NoError(t, db.Create(&legacy).Error)
+
+	result, err := backfill.ItemNames(ctx, db, menuClient)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Updated)
+	assert.Equal(t, []uint{9999}, result.Missing)
+
+	var items []ordermodels.OrderItem
+	require.NoError(t, db.Order("id").Find(&items).Error)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Backfill Muffin", items[0].Name)
+	assert.Equal(t, "Blueberry muffin", items[0].Description)
+	assert.InDelta(t, 2.75, items[0].Price, 0.001, "backfill must not touch snapshotted prices")
+	assert.Empty(t, items[1].Name)
+
+	// Running again is a no-op
+	result, err = backfill.ItemNames(ctx, db, menuClient)
+	require.NoError(t, err)
+	assert.Zero(t, result.Updated)
+}
+
+func TestIntegration_AmendOrder(t *testing.T) {
+	setupUserService(t)
+	setupMenuService(t)
+
+	ctx := context.Background()
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	setupOrderService(t, userConn, menuConn)
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	userResp, err := userClient.CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "Amend User",
+		Email: "amend@test.com",
+	})
+	require.NoError(t, err)
+
+	sandwich, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Amend Sandwich", Price: 5.00})
+	require.NoError(t, err)
+	cookie, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Amend Cookie", Price: 1.50})
+	require.NoError(t, err)
+	drink, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Amend Lemonade", Price: 2.25})
+	require.NoError(t, err)
+
+	orderResp, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: userResp.User.Id,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: sandwich.MenuItem.Id, Quantity: 1},
+			{MenuItemId: cookie.MenuItem.Id, Quantity: 1},
+		},
+	})
+	require.NoError(t, err)
+	orderID := orderResp.Order.Id
+
+	// Add a drink, double the sandwich and drop the cookie
+	amendResp, err := orderClient.AmendOrder(ctx, &orderv1.AmendOrderRequest{
+		Id: orderID,
+		Changes: []*orderv1.OrderItemChange{
+			{MenuItemId: drink.MenuItem.Id, Quantity: 1},
+			{MenuItemId: sandwich.MenuItem.Id, Quantity: 2},
+			{MenuItemId: cookie.MenuItem.Id, Quantity: 0},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), amendResp.Order.Revision)
+	quantities := map[uint32]int32{}
+	for _, item := range amendResp.Order.OrderItems {
+		quantities[item.MenuItemId] = item.Quantity
+	}
+	assert.Equal(t, map[uint32]int32{drink.MenuItem.Id: 1, sandwich.MenuItem.Id: 2}, quantities)
+
+	require.Len(t, amendResp.Revision.Items, 3)
+	assert.Equal(t, "Amend Lemonade", amendResp.Revision.Items[0].Name)
+	assert.Equal(t, int32(0), amendResp.Revision.Items[0].OldQuantity)
+	assert.Equal(t, int32(1), amendResp.Revision.Items[0].NewQuantity)
+	assert.InDelta(t, 2.25, amendResp.Revision.Items[0].Price, 0.001)
+	assert.Equal(t, int32(1), amendResp.Revision.Items[2].OldQuantity)
+	assert.Equal(t, int32(0), amendResp.Revision.Items[2].NewQuantity)
+
+	// The kitchen sees the revision history on the order
+	getResp, err := orderClient.GetOrder(ctx, &orderv1.GetOrderRequest{Id: orderID})
+	require.NoError(t, err)
+	require.Len(t, getResp.Order.Revisions, 1)
+	assert.Len(t, getResp.Order.Revisions[0].Items, 3)
+
+	// No amendments once preparation has begun
+	require.NoError(t, orderdatabase.DB.Model(&ordermodels.Order{}).
+		Where("id = ?", orderID).Update("status", "preparing").Error)
+
+	_, err = orderClient.AmendOrder(ctx, &orderv1.AmendOrderRequest{
+		Id:      orderID,
+		Changes: []*orderv1.OrderItemChange{{MenuItemId: drink.MenuItem.Id, Quantity: 2}},
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestIntegration_OrderWithModifiers(t *testing.T) {
+	setupUserService(t)
+	setupMenuService(t)
+
+	ctx := context.Background()
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	setupOrderService(t, userConn, menuConn)
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	userResp, err := userClient.CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "Modifier User",
+		Email: "modifiers@test.com",
+	})
+	require.NoError(t, err)
+
+	coffee, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name:  "Modifier Coffee",
+		Price: 3.00,
+		ModifierGroups: []*menuv1.ModifierGroup{
+			{Name: "Size", Required: true, MaxSelections: 1, Options: []*menuv1.ModifierOption{
+				{Name: "Regular"},
+				{Name: "Large", PriceDelta: 0.80},
+			}},
+			{Name: "Milk", MaxSelections: 1, Options: []*menuv1.ModifierOption{
+				{Name: "Oat", PriceDelta: 0.50},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, coffee.MenuItem.ModifierGroups, 2)
+	large := coffee.MenuItem.ModifierGroups[0].Options[1].Id
+	oat := coffee.MenuItem.ModifierGroups[1].Options[0].Id
+
+	// Modifier groups round-trip through GetMenuItem
+	getResp, err := menuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: coffee.MenuItem.Id})
+	require.NoError(t, err)
+	require.Len(t, getResp.MenuItem.ModifierGroups, 2)
+	assert.True(t, getResp.MenuItem.ModifierGroups[0].Required)
+
+	t.Run("required group enforced", func(t *testing.T) {
+		_, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: coffee.MenuItem.Id, Quantity: 1, ModifierOptionIds: []uint32{oat}}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	orderResp, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: userResp.User.Id,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: coffee.MenuItem.Id, Quantity: 2, ModifierOptionIds: []uint32{large, oat}}},
+	})
+	require.NoError(t, err)
+
+	item := orderResp.Order.OrderItems[0]
+	assert.InDelta(t, 3.00, item.Price, 0.001)
+	assert.InDelta(t, 8.60, item.LineTotal, 0.001)
+	require.Len(t, item.Modifiers, 2)
+	assert.Equal(t, "Size", item.Modifiers[0].GroupName)
+	assert.Equal(t, "Large", item.Modifiers[0].Name)
+
+	// Modifier snapshots survive a reload and show up on the receipt
+	order, err := orderClient.GetOrder(ctx, &orderv1.GetOrderRequest{Id: orderResp.Order.Id})
+	require.NoError(t, err)
+	assert.Len(t, order.Order.OrderItems[0].Modifiers, 2)
+
+	receiptResp, err := orderClient.GetOrderReceipt(ctx, &orderv1.GetOrderReceiptRequest{Id: orderResp.Order.Id})
+	require.NoError(t, err)
+	assert.Contains(t, string(receiptResp.Content), "+ Large")
+	assert.Contains(t, string(receiptResp.Content), "8.60")
+
+	regular := coffee.MenuItem.ModifierGroups[0].Options[0].Id
+
+	t.Run("amend one of two lines of the same item", func(t *testing.T) {
+		orderResp, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items: []*orderv1.OrderItemRequest{
+				{MenuItemId: coffee.MenuItem.Id, Quantity: 1, ModifierOptionIds: []uint32{large, oat}},
+				{MenuItemId: coffee.MenuItem.Id, Quantity: 1, ModifierOptionIds: []uint32{regular}},
+			},
+		})
+		require.NoError(t, err)
+		largeLine, regularLine := orderResp.Order.OrderItems[0], orderResp.Order.OrderItems[1]
+
+		// By line ID
+		amendResp, err := orderClient.AmendOrder(ctx, &orderv1.AmendOrderRequest{
+			Id:      orderResp.Order.Id,
+			Changes: []*orderv1.OrderItemChange{{MenuItemId: coffee.MenuItem.Id, OrderItemId: regularLine.Id, Quantity: 3}},
+		})
+		require.NoError(t, err)
+		require.Len(t, amendResp.Order.OrderItems, 2)
+		for _, item := range amendResp.Order.OrderItems {
+			if item.Id == largeLine.Id {
+				assert.Equal(t, int32(1), item.Quantity)
+				assert.Len(t, item.Modifiers, 2, "the other line keeps its modifiers")
+			} else {
+				assert.Equal(t, regularLine.Id, item.Id)
+				assert.Equal(t, int32(3), item.Quantity)
+			}
+		}
+		require.Len(t, amendResp.Revision.Items, 1)
+		assert.Equal(t, regularLine.Id, amendResp.Revision.Items[0].OrderItemId)
+		assert.InDelta(t, 3.00, amendResp.Revision.Items[0].Price, 0.001)
+
+		// By menu item and modifiers, priced with the line's own modifiers
+		amendResp, err = orderClient.AmendOrder(ctx, &orderv1.AmendOrderRequest{
+			Id:      orderResp.Order.Id,
+			Changes: []*orderv1.OrderItemChange{{MenuItemId: coffee.MenuItem.Id, ModifierOptionIds: []uint32{oat, large}, Quantity: 2}},
+		})
+		require.NoError(t, err)
+		require.Len(t, amendResp.Order.OrderItems, 2)
+		assert.Equal(t, largeLine.Id, amendResp.Revision.Items[0].OrderItemId)
+		assert.InDelta(t, 4.30, amendResp.Revision.Items[0].Price, 0.001)
+
+		// A line the order lacks is added with its required modifier
+		_, err = orderClient.AmendOrder(ctx, &orderv1.AmendOrderRequest{
+			Id:      orderResp.Order.Id,
+			Changes: []*orderv1.OrderItemChange{{MenuItemId: coffee.MenuItem.Id, ModifierOptionIds: []uint32{oat}, Quantity: 1}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		amendResp, err = orderClient.AmendOrder(ctx, &orderv1.AmendOrderRequest{
+			Id:      orderResp.Order.Id,
+			Changes: []*orderv1.OrderItemChange{{MenuItemId: coffee.MenuItem.Id, ModifierOptionIds: []uint32{large}, Quantity: 1}},
+		})
+		require.NoError(t, err)
+		require.Len(t, amendResp.Order.OrderItems, 3)
+		assert.InDelta(t, 3.80, amendResp.Revision.Items[0].Price, 0.001)
+		assert.NotZero(t, amendResp.Revision.Items[0].OrderItemId)
+	})
+}
+
+func TestIntegration_BundleOrder(t *testing.T) {
+	setupUserService(t)
+	setupMenuService(t)
+
+	ctx := context.Background()
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	setupOrderService(t, userConn, menuConn)
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	userResp, err := userClient.CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "Bundle User",
+		Email: "bundle@test.com",
+	})
+	require.NoError(t, err)
+
+	create := func(req *menuv1.CreateMenuItemRequest) *menuv1.MenuItem {
+		resp, err := menuClient.CreateMenuItem(ctx, req)
+		require.NoError(t, err)
+		return resp.MenuItem
+	}
+	ham := create(&menuv1.CreateMenuItemRequest{Name: "Ham Sandwich", Price: 5.00})
+	club := create(&menuv1.CreateMenuItemRequest{Name: "Club Sandwich", Price: 6.50})
+	latte := create(&menuv1.CreateMenuItemRequest{Name: "Bundle Latte", Price: 3.50, ModifierGroups: []*menuv1.ModifierGroup{
+		{Name: "Milk", MaxSelections: 1, Options: []*menuv1.ModifierOption{{Name: "Oat", PriceDelta: 0.50}}},
+	}})
+	crisps := create(&menuv1.CreateMenuItemRequest{Name: "Crisps", Price: 1.50})
+
+	lunchDeal := create(&menuv1.CreateMenuItemRequest{
+		Name:  "Lunch Deal",
+		Price: 8.00,
+		BundleSlots: []*menuv1.BundleSlot{
+			{Name: "Sandwich", Choices: []*menuv1.BundleChoice{{MenuItemId: ham.Id}, {MenuItemId: club.Id, Upcharge: 1.00}}},
+			{Name: "Drink", Choices: []*menuv1.BundleChoice{{MenuItemId: latte.Id}}},
+			{Name: "Snack", Choices: []*menuv1.BundleChoice{{MenuItemId: crisps.Id}}},
+		},
+	})
+	require.Len(t, lunchDeal.BundleSlots, 3)
+	sandwichSlot := lunchDeal.BundleSlots[0].Id
+	drinkSlot := lunchDeal.BundleSlots[1].Id
+	snackSlot := lunchDeal.BundleSlots[2].Id
+
+	t.Run("bundles cannot be nested", func(t *testing.T) {
+		_, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+			Name:        "Double Deal",
+			Price:       15.00,
+			BundleSlots: []*menuv1.BundleSlot{{Name: "Deal", Choices: []*menuv1.BundleChoice{{MenuItemId: lunchDeal.Id}}}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("choice outside the slot is rejected", func(t *testing.T) {
+		_, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items: []*orderv1.OrderItemRequest{{MenuItemId: lunchDeal.Id, Quantity: 1, BundleSelections: []*orderv1.BundleSelection{
+				{SlotId: sandwichSlot, MenuItemId: crisps.Id},
+				{SlotId: drinkSlot, MenuItemId: latte.Id},
+				{SlotId: snackSlot, MenuItemId: crisps.Id},
+			}}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("missing slot is rejected", func(t *testing.T) {
+		_, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: lunchDeal.Id, Quantity: 1}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	orderResp, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: userResp.User.Id,
+		Items: []*orderv1.OrderItemRequest{{MenuItemId: lunchDeal.Id, Quantity: 2, BundleSelections: []*orderv1.BundleSelection{
+			{SlotId: sandwichSlot, MenuItemId: club.Id},
+			{SlotId: drinkSlot, MenuItemId: latte.Id, ModifierOptionIds: []uint32{latte.ModifierGroups[0].Options[0].Id}},
+			{SlotId: snackSlot, MenuItemId: crisps.Id},
+		}}},
+	})
+	require.NoError(t, err)
+
+	// Bundle price plus the club upcharge and oat milk, not the component prices
+	item := orderResp.Order.OrderItems[0]
+	assert.InDelta(t, 8.00, item.Price, 0.001)
+	assert.InDelta(t, 19.00, item.LineTotal, 0.001)
+
+	// The kitchen sees each component after a reload
+	order, err := orderClient.GetOrder(ctx, &orderv1.GetOrderRequest{Id: orderResp.Order.Id})
+	require.NoError(t, err)
+	components := order.Order.OrderItems[0].Components
+	require.Len(t, components, 3)
+	assert.Equal(t, "Club Sandwich", components[0].Name)
+	assert.Equal(t, "Bundle Latte", components[1].Name)
+	require.Len(t, components[1].Modifiers, 1)
+	assert.Equal(t, "Oat", components[1].Modifiers[0].Name)
+	assert.Equal(t, "Crisps", components[2].Name)
+	assert.Empty(t, order.Order.OrderItems[0].Modifiers)
+
+	receiptResp, err := orderClient.GetOrderReceipt(ctx, &orderv1.GetOrderReceiptRequest{Id: orderResp.Order.Id})
+	require.NoError(t, err)
+	assert.Contains(t, string(receiptResp.Content), "* Club Sandwich")
+	assert.Contains(t, string(receiptResp.Content), "19.00")
+
+	t.Run("amend one of two bundles of the same item", func(t *testing.T) {
+		hamDeal := []*orderv1.BundleSelection{
+			{SlotId: sandwichSlot, MenuItemId: ham.Id},
+			{SlotId: drinkSlot, MenuItemId: latte.Id},
+			{SlotId: snackSlot, MenuItemId: crisps.Id},
+		}
+		clubDeal := []*orderv1.BundleSelection{
+			{SlotId: sandwichSlot, MenuItemId: club.Id},
+			{SlotId: drinkSlot, MenuItemId: latte.Id, ModifierOptionIds: []uint32{latte.ModifierGroups[0].Options[0].Id}},
+			{SlotId: snackSlot, MenuItemId: crisps.Id},
+		}
+		orderResp, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items: []*orderv1.OrderItemRequest{
+				{MenuItemId: lunchDeal.Id, Quantity: 1, BundleSelections: clubDeal},
+				{MenuItemId: lunchDeal.Id, Quantity: 1, BundleSelections: hamDeal},
+			},
+		})
+		require.NoError(t, err)
+		clubLine := orderResp.Order.OrderItems[0]
+
+		// The club deal doubles at its own price; the ham deal is untouched
+		amendResp, err := orderClient.AmendOrder(ctx, &orderv1.AmendOrderRequest{
+			Id:      orderResp.Order.Id,
+			Changes: []*orderv1.OrderItemChange{{MenuItemId: lunchDeal.Id, OrderItemId: clubLine.Id, Quantity: 2}},
+		})
+		require.NoError(t, err)
+		require.Len(t, amendResp.Order.OrderItems, 2)
+		for _, item := range amendResp.Order.OrderItems {
+			require.Len(t, item.Components, 3)
+			if item.Id == clubLine.Id {
+				assert.Equal(t, "Club Sandwich", item.Components[0].Name)
+				assert.Equal(t, int32(2), item.Quantity)
+			} else {
+				assert.Equal(t, "Ham Sandwich", item.Components[0].Name)
+				assert.Equal(t, int32(1), item.Quantity)
+			}
+		}
+		assert.InDelta(t, 9.50, amendResp.Revision.Items[0].Price, 0.001)
+
+		// Matched by selections rather than line ID
+		amendResp, err = orderClient.AmendOrder(ctx, &orderv1.AmendOrderRequest{
+			Id:      orderResp.Order.Id,
+			Changes: []*orderv1.OrderItemChange{{MenuItemId: lunchDeal.Id, BundleSelections: hamDeal, Quantity: 3}},
+		})
+		require.NoError(t, err)
+		require.Len(t, amendResp.Order.OrderItems, 2)
+		assert.NotEqual(t, clubLine.Id, amendResp.Revision.Items[0].OrderItemId)
+		assert.InDelta(t, 8.00, amendResp.Revision.Items[0].Price, 0.001)
+
+		// A bundle added by amendment needs its selections
+		_, err = orderClient.AmendOrder(ctx, &orderv1.AmendOrderRequest{
+			Id:      orderResp.Order.Id,
+			Changes: []*orderv1.OrderItemChange{{MenuItemId: lunchDeal.Id, Quantity: 1}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		// Removing every line would leave an empty order
+		_, err = orderClient.AmendOrder(ctx, &orderv1.AmendOrderRequest{
+			Id: orderResp.Order.Id,
+			Changes: []*orderv1.OrderItemChange{
+				{MenuItemId: lunchDeal.Id, OrderItemId: clubLine.Id, Quantity: 0},
+				{MenuItemId: lunchDeal.Id, BundleSelections: hamDeal, Quantity: 0},
+			},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "empty")
+	})
+}
+
+func TestIntegration_GracefulShutdownCompletesInFlightOrders(t *testing.T) {
+	setupUserService(t)
+	setupMenuService(t)
+	setupOrderDB(t)
+
+	ctx := context.Background()
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	userResp, err := userv1.NewUserServiceClient(userConn).CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "Shutdown User",
+		Email: "shutdown@test.com",
+	})
+	require.NoError(t, err)
+	itemResp, err := menuv1.NewMenuServiceClient(menuConn).CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name:  "Shutdown Coffee",
+		Price: 2.50,
+	})
+	require.NoError(t, err)
+
+	// Order service whose CreateOrder is held until released, so it is
+	// still in flight when shutdown starts
+	started := make(chan struct{})
+	release := make(chan struct{})
+	hold := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == orderv1.OrderService_CreateOrder_FullMethodName {
+			close(started)
+			<-release
+		}
+		return handler(ctx, req)
+	}
+	listener := bufconn.Listen(bufSize)
+	s := grpc.NewServer(grpc.UnaryInterceptor(hold))
+	orderv1.RegisterOrderServiceServer(s, &ordergrpc.OrderServer{
+		UserClient: userv1.NewUserServiceClient(userConn),
+		MenuClient: menuv1.NewMenuServiceClient(menuConn),
+	})
+	monitor := health.NewMonitor([]string{orderv1.OrderService_ServiceDesc.ServiceName}, health.DBCheck(orderdatabase.DB))
+	monitor.Register(s)
+	require.True(t, monitor.CheckNow(ctx))
+	go func() {
+		_ = s.Serve(listener)
+	}()
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(listener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+	healthClient := healthpb.NewHealthClient(orderConn)
+
+	type result struct {
+		resp *orderv1.CreateOrderResponse
+		err  error
+	}
+	inFlight := make(chan result, 1)
+	go func() {
+		resp, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: itemResp.MenuItem.Id, Quantity: 1}},
+		})
+		inFlight <- result{resp, err}
+	}()
+	<-started
+
+	// Shut down the way main does: NOT_SERVING first, then drain
+	monitor.Shutdown()
+	healthResp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, healthResp.Status)
+
+	stopped := make(chan bool, 1)
+	go func() { stopped <- lifecycle.GracefulStop(s, 5*time.Second) }()
+
+	// New connections are refused while the in-flight order drains
+	require.Eventually(t, func() bool {
+		_, err := listener.Dial()
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+	got := <-inFlight
+	require.NoError(t, got.err, "in-flight order should complete during shutdown")
+	assert.True(t, <-stopped, "server should drain within the timeout")
+
+	// The order was saved before the server stopped
+	var saved ordermodels.Order
+	require.NoError(t, orderdatabase.DB.First(&saved, got.resp.Order.Id).Error)
+	assert.Equal(t, uint(userResp.User.Id), saved.UserID)
+}
+
+// replica is one menu-service instance whose calls are counted and can be
+// made to fail as if the instance were broken
+type replica struct {
+	mu      sync.Mutex
+	calls   int
+	failing bool
+}
+
+func (r *replica) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	r.mu.Lock()
+	r.calls++
+	failing := r.failing
+	r.mu.Unlock()
+	if failing {
+		return nil, status.Errorf(codes.Unavailable, "replica is down")
+	}
+	return handler(ctx, req)
+}
+
+func (r *replica) take() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := r.calls
+	r.calls = 0
+	return calls
+}
+
+// serveMenuReplica starts a menu-service instance sharing the menu
+// database, with its calls going through r
+func serveMenuReplica(t *testing.T, r *replica) *bufconn.Listener {
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(grpc.UnaryInterceptor(r.intercept))
+	menuv1.RegisterMenuServiceServer(s, menugrpc.NewMenuServer())
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+	return lis
+}
+
+// addrDialer dials the listener registered under each address
+func addrDialer(listeners map[string]*bufconn.Listener) func(context.Context, string) (net.Conn, error) {
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		lis, ok := listeners[addr]
+		if !ok {
+			return nil, fmt.Errorf("no listener at %s", addr)
+		}
+		return lis.DialContext(ctx)
+	}
+}
+
+func TestIntegration_MenuReplicasLoadBalanced(t *testing.T) {
+	setupMenuService(t)
+
+	// Three menu-service replicas sharing the database, each reached
+	// through its own address
+	addrs := []string{"menu-1:9092", "menu-2:9092", "menu-3:9092"}
+	replicas := map[string]*replica{}
+	listeners := map[string]*bufconn.Listener{}
+	for _, addr := range addrs {
+		replicas[addr] = &replica{}
+		listeners[addr] = serveMenuReplica(t, replicas[addr])
+	}
+
+	// Retry and check for outliers often enough for the test
+	policy := grpcclient.MenuServicePolicy()
+	policy.Retry.InitialBackoff = time.Millisecond
+	policy.Retry.MaxBackoff = 5 * time.Millisecond
+	policy.Balancer.Interval = 50 * time.Millisecond
+	policy.Balancer.RequestVolume = 5
+	conn, err := grpcclient.Dial(strings.Join(addrs, ","), policy,
+		grpc.WithContextDialer(addrDialer(listeners)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := menuv1.NewMenuServiceClient(conn)
+	ctx := context.Background()
+
+	takeAll := func() map[string]int {
+		calls := map[string]int{}
+		for addr, r := range replicas {
+			calls[addr] = r.take()
+		}
+		return calls
+	}
+
+	// Warm up until every replica is connected and taking calls
+	require.Eventually(t, func() bool {
+		_, err := client.GetMenu(ctx, &menuv1.GetMenuRequest{})
+		require.NoError(t, err)
+		for _, r := range replicas {
+			r.mu.Lock()
+			calls := r.calls
+			r.mu.Unlock()
+			if calls == 0 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	takeAll()
+
+	for i := 0; i < 30; i++ {
+		_, err := client.GetMenu(ctx, &menuv1.GetMenuRequest{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, map[string]int{"menu-1:9092": 10, "menu-2:9092": 10, "menu-3:9092": 10}, takeAll(), "calls should be spread evenly")
+
+	// A broken replica costs retries until the next check ejects it, and
+	// reads still succeed throughout
+	replicas["menu-2:9092"].mu.Lock()
+	replicas["menu-2:9092"].failing = true
+	replicas["menu-2:9092"].mu.Unlock()
+	require.Eventually(t, func() bool {
+		takeAll()
+		for i := 0; i < 30; i++ {
+			_, err := client.GetMenu(ctx, &menuv1.GetMenuRequest{})
+			require.NoError(t, err)
+		}
+		return takeAll()["menu-2:9092"] == 0
+	}, 5*time.Second, 10*time.Millisecond, "replica should be ejected once most of its calls fail")
+
+	for i := 0; i < 30; i++ {
+		_, err := client.GetMenu(ctx, &menuv1.GetMenuRequest{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, map[string]int{"menu-1:9092": 15, "menu-2:9092": 0, "menu-3:9092": 15}, takeAll(), "ejected replica should get no calls")
+}
+
+func TestIntegration_OrderServiceDiscoversReplicas(t *testing.T) {
+	setupUserService(t)
+	setupMenuService(t)
+	setupOrderDB(t)
+	// Restored last, once the registrations and resolvers have stopped
+	ttl, refresh := discovery.TTL, discovery.RefreshInterval
+	t.Cleanup(func() { discovery.TTL, discovery.RefreshInterval = ttl, refresh })
+	discovery.TTL, discovery.RefreshInterval = 60*time.Millisecond, 10*time.Millisecond
+	ctx := context.Background()
+
+	// Two menu-service replicas and the user service, each registering in
+	// a shared registry directory as the services do at startup
+	registry := discovery.NewFile(t.TempDir())
+	listeners := map[string]*bufconn.Listener{"user-1:9091": userListener}
+	replicas := map[string]*replica{"menu-1:9092": {}, "menu-2:9092": {}}
+	healthy := map[string]*atomic.Bool{}
+	register := func(service, addr string) {
+		instance, err := discovery.NewInstance(service, addr)
+		require.NoError(t, err)
+		healthy[addr] = &atomic.Bool{}
+		healthy[addr].Store(true)
+		reg, err := discovery.Register(ctx, registry, instance, healthy[addr].Load)
+		require.NoError(t, err)
+		t.Cleanup(func() { reg.Deregister(context.Background()) })
+	}
+	register("user-service", "user-1:9091")
+	for addr, r := range replicas {
+		listeners[addr] = serveMenuReplica(t, r)
+		register("menu-service", addr)
+	}
+
+	orderServer, err := ordergrpc.NewOrderServer("discovery:///user-service", "discovery:///menu-service", insecure.NewCredentials(),
+		discovery.DialOption(registry), grpc.WithContextDialer(addrDialer(listeners)))
+	require.NoError(t, err)
+	defer orderServer.Close()
+
+	userResp, err := orderServer.UserClient.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Discovery User", Email: fmt.Sprintf("discovery-%d@test.com", time.Now().UnixNano())})
+	require.NoError(t, err)
+	itemResp, err := orderServer.MenuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Discovered Coffee", Price: 3})
+	require.NoError(t, err)
+	createOrders := func(n int) {
+		for i := 0; i < n; i++ {
+			_, err := orderServer.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+				UserId: userResp.User.Id,
+				Items:  []*orderv1.OrderItemRequest{{MenuItemId: itemResp.MenuItem.Id, Quantity: 1}},
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	// Both replicas are found and share the menu lookups
+	require.Eventually(t, func() bool {
+		createOrders(1)
+		return replicas["menu-1:9092"].take() > 0 && replicas["menu-2:9092"].take() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A replica failing its health checks drops out of the registry, and
+	// so out of the order service's connection
+	healthy["menu-2:9092"].Store(false)
+	time.Sleep(discovery.TTL)
+	replicas["menu-2:9092"].take()
+	createOrders(6)
+	assert.Zero(t, replicas["menu-2:9092"].take(), "unhealthy replica should not be called")
+	assert.NotZero(t, replicas["menu-1:9092"].take())
+
+	// With no healthy replica left, orders fail fast as unavailable rather
+	// than going to the replicas the registry no longer lists
+	healthy["menu-1:9092"].Store(false)
+	time.Sleep(discovery.TTL)
+	replicas["menu-1:9092"].take()
+	_, err = orderServer.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: userResp.User.Id,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: itemResp.MenuItem.Id, Quantity: 1}},
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Zero(t, replicas["menu-1:9092"].take()+replicas["menu-2:9092"].take(), "no replica should be called")
+}
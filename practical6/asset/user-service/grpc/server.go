@@ -30,7 +30,7 @@ func (s *UserServer) CreateUser(ctx context.Context, req *userv1.CreateUserReque
 		IsCafeOwner: req.IsCafeOwner,
 	}
 
-	if err := database.DB.Create(&user).Error; err != nil {
+	if err := database.DB.WithContext(ctx).Create(&user).Error; err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
 	}
 
@@ -42,7 +42,7 @@ func (s *UserServer) CreateUser(ctx context.Context, req *userv1.CreateUserReque
 // GetUser retrieves a user by ID
 func (s *UserServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
 	var user models.User
-	if err := database.DB.First(&user, req.Id).Error; err != nil {
+	if err := database.DB.WithContext(ctx).First(&user, req.Id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
@@ -57,7 +57,7 @@ func (s *UserServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*
 // GetUsers retrieves all users
 func (s *UserServer) GetUsers(ctx context.Context, req *userv1.GetUsersRequest) (*userv1.GetUsersResponse, error) {
 	var users []models.User
-	if err := database.DB.Find(&users).Error; err != nil {
+	if err := database.DB.WithContext(ctx).Find(&users).Error; err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get users: %v", err)
 	}
 
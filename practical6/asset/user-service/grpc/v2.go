@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/douglasswm/student-cafe-common/protoconv"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	userv2 "github.com/douglasswm/student-cafe-protos/gen/go/user/v2"
+)
+
+// UserServerV2 implements the v2 UserService by converting to and from
+// the v1 server, so both versions share one implementation
+type UserServerV2 struct {
+	userv2.UnimplementedUserServiceServer
+	v1 userv1.UserServiceServer
+}
+
+// NewUserServerV2 creates a v2 user server backed by v1
+func NewUserServerV2(v1 userv1.UserServiceServer) *UserServerV2 {
+	return &UserServerV2{v1: v1}
+}
+
+// CreateUser creates a new user
+func (s *UserServerV2) CreateUser(ctx context.Context, req *userv2.CreateUserRequest) (*userv2.CreateUserResponse, error) {
+	resp, err := s.v1.CreateUser(ctx, protoconv.CreateUserRequestToV1(req))
+	if err != nil {
+		return nil, err
+	}
+	return &userv2.CreateUserResponse{User: protoconv.UserToV2(resp.User)}, nil
+}
+
+// GetUser retrieves a user by ID
+func (s *UserServerV2) GetUser(ctx context.Context, req *userv2.GetUserRequest) (*userv2.GetUserResponse, error) {
+	resp, err := s.v1.GetUser(ctx, &userv1.GetUserRequest{Id: req.Id})
+	if err != nil {
+		return nil, err
+	}
+	return &userv2.GetUserResponse{User: protoconv.UserToV2(resp.User)}, nil
+}
+
+// GetUsers retrieves all users
+func (s *UserServerV2) GetUsers(ctx context.Context, req *userv2.GetUsersRequest) (*userv2.GetUsersResponse, error) {
+	resp, err := s.v1.GetUsers(ctx, &userv1.GetUsersRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &userv2.GetUsersResponse{Users: protoconv.UsersToV2(resp.Users)}, nil
+}
@@ -1,46 +1,154 @@
 package main
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"log/slog"
 	"net"
 	"os"
+	"time"
 	"user-service/database"
 	grpcserver "user-service/grpc"
 
+	"github.com/douglasswm/student-cafe-common/config"
+	"github.com/douglasswm/student-cafe-common/discovery"
+	"github.com/douglasswm/student-cafe-common/health"
+	"github.com/douglasswm/student-cafe-common/lifecycle"
+	"github.com/douglasswm/student-cafe-common/logging"
+	"github.com/douglasswm/student-cafe-common/metrics"
+	"github.com/douglasswm/student-cafe-common/mtls"
+	"github.com/douglasswm/student-cafe-common/telemetry"
+	"github.com/douglasswm/student-cafe-common/validation"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	userv2 "github.com/douglasswm/student-cafe-protos/gen/go/user/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// Connect to dedicated user database
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		dsn = "host=localhost user=postgres password=postgres dbname=user_db port=5432 sslmode=disable"
+	// Load configuration from env, an optional YAML file and flags
+	configOpts := config.Options{
+		Service: "user-service",
+		Defaults: config.Config{
+			GRPCPort:    "9091",
+			AdminPort:   "9101",
+			DatabaseURL: "host=localhost user=postgres password=postgres dbname=user_db port=5432 sslmode=disable",
+		},
+		Required: []string{"grpc_port", "admin_port", "database_url"},
+		Args:     os.Args[1:],
+	}
+	cfg, err := config.Load(configOpts)
+	if err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
 	}
 
-	if err := database.Connect(dsn); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	// JSON logs tagged with the service name
+	logger := logging.Setup(cfg.Logging("user-service"))
+	slog.Info("Configuration loaded", "config", cfg)
+
+	// Cancelled on SIGINT/SIGTERM to start a graceful shutdown
+	ctx, stop := lifecycle.SignalContext()
+	defer stop()
+
+	// Apply log level and timeout changes without a restart
+	reloader := config.NewReloader(configOpts, cfg)
+	reloader.OnChange(config.ApplyLogLevel)
+	go reloader.Watch(ctx, config.DefaultWatchInterval)
+
+	// Set up tracing before anything that makes calls
+	shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.ConfigFromEnv("user-service"))
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", "error", err)
 	}
+	defer shutdownTracing(context.Background())
 
-	// Get gRPC port from environment
-	grpcPort := os.Getenv("GRPC_PORT")
-	if grpcPort == "" {
-		grpcPort = "9091"
+	// Connect to dedicated user database
+	if err := database.Connect(cfg.DatabaseURL); err != nil {
+		logging.Fatal("Failed to connect to database", "error", err)
 	}
 
 	// Start listening on TCP port
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		logging.Fatal("Failed to listen on gRPC port", "port", cfg.GRPCPort, "error", err)
+	}
+
+	// Serve metrics on a separate admin port
+	adminServer, err := metrics.ServeAdmin(":"+cfg.AdminPort, metrics.NewAdminMux(prometheus.DefaultGatherer))
+	if err != nil {
+		logging.Fatal("Failed to start admin server", "port", cfg.AdminPort, "error", err)
+	}
+
+	// The registry this service announces itself in and, when configured,
+	// looks up discovery:/// addresses in
+	registry, err := discovery.New(cfg.Discovery())
+	if err != nil {
+		logging.Fatal("Failed to set up service discovery", "error", err)
+	}
+
+	// Require callers to present a certificate when mutual TLS is configured
+	creds, err := mtls.ServerCredentials(cfg.TLS())
 	if err != nil {
-		log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
+		logging.Fatal("Failed to load TLS certificates", "error", err)
 	}
 
 	// Create and register gRPC server
-	s := grpc.NewServer()
-	userv1.RegisterUserServiceServer(s, grpcserver.NewUserServer())
+	grpcMetrics := metrics.NewGRPCServerMetrics(prometheus.DefaultRegisterer)
+	opts := []grpc.ServerOption{grpc.Creds(creds), telemetry.ServerOption()}
+	opts = append(opts, grpcMetrics.ServerOptions()...)
+	opts = append(opts, logging.ServerOptions(logger)...)
+	opts = append(opts, validation.ServerOption())
+	s := grpc.NewServer(opts...)
+	userServer := grpcserver.NewUserServer()
+	userv1.RegisterUserServiceServer(s, userServer)
+	userv2.RegisterUserServiceServer(s, grpcserver.NewUserServerV2(userServer))
+
+	// Let tools such as grpcurl list and call the services without the proto files
+	reflection.Register(s)
+
+	// Report SERVING only while the database is healthy
+	healthMonitor := health.NewMonitor([]string{userv1.UserService_ServiceDesc.ServiceName, userv2.UserService_ServiceDesc.ServiceName}, health.DBCheck(database.DB))
+	healthMonitor.Register(s)
+	go healthMonitor.Run(ctx)
+
+	slog.Info("User service (gRPC only) starting", "port", cfg.GRPCPort)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			logging.Fatal("gRPC server failed", "error", err)
+		}
+	}()
+
+	// List this instance in the registry while its health checks pass
+	var registration *discovery.Registration
+	if registry != nil {
+		instance, err := discovery.NewInstance("user-service", cfg.Advertise())
+		if err != nil {
+			logging.Fatal("Invalid advertise address", "error", err)
+		}
+		if registration, err = discovery.Register(ctx, registry, instance, healthMonitor.Serving); err != nil {
+			logging.Fatal("Failed to register with service discovery", "error", err)
+		}
+	}
+
+	<-ctx.Done()
+	slog.Info("Shutting down user-service")
+
+	// Leave the registry first so new connections go to other instances
+	if registration != nil {
+		deregisterCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := registration.Deregister(deregisterCtx); err != nil {
+			slog.Error("Failed to deregister from service discovery", "error", err)
+		}
+		cancel()
+	}
+
+	// Tell clients to stop sending new requests, then let in-flight ones finish
+	healthMonitor.Shutdown()
+	lifecycle.GracefulStop(s, reloader.Current().ShutdownTimeout)
 
-	log.Printf("User service (gRPC only) starting on :%s", grpcPort)
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("gRPC server failed: %v", err)
+	adminServer.Close()
+	if err := database.Close(); err != nil {
+		slog.Error("Failed to close database", "error", err)
 	}
+	slog.Info("User service stopped")
 }